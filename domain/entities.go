@@ -2,11 +2,127 @@ package domain
 
 import "time"
 
-// LanguageStats represents statistics about programming language usage
+// LanguageStats represents statistics about programming language usage.
+// DisplayPercentage is Percentage rounded for display; the display
+// percentages of a list always add up to exactly 100.
 type LanguageStats struct {
-	Language   string
-	Bytes      int
-	Percentage float64
+	Language          string
+	Bytes             int
+	Percentage        float64
+	DisplayPercentage float64
+}
+
+// LanguageGroup merges several languages into one bucket called Name
+type LanguageGroup struct {
+	Name      string
+	Languages []string
+}
+
+// LanguageRules customizes how languages are bucketed and displayed. Groups
+// are applied first, then Renames (keyed by language or group name). Pinned
+// buckets are always shown, even outside the top N.
+type LanguageRules struct {
+	Groups  []LanguageGroup
+	Renames map[string]string
+	Pinned  []string
+}
+
+// TimeWindow limits the analysis to [Since, Until). A zero bound leaves that
+// side open, so the zero TimeWindow covers the whole history.
+type TimeWindow struct {
+	Since time.Time
+	Until time.Time
+	// Spec is the window as the user described it, such as "90d..", which
+	// stays the same from run to run while rolling bounds move
+	Spec string
+}
+
+// IsZero reports whether the window covers the whole history
+func (w TimeWindow) IsZero() bool {
+	return w.Since.IsZero() && w.Until.IsZero()
+}
+
+// Contains reports whether t falls inside the window
+func (w TimeWindow) Contains(t time.Time) bool {
+	if !w.Since.IsZero() && t.Before(w.Since) {
+		return false
+	}
+	return w.Until.IsZero() || t.Before(w.Until)
+}
+
+// CalendarWeeks is the number of week columns in a contribution calendar
+const CalendarWeeks = 53
+
+// ContributionDay is one cell of a contribution calendar. Level is 0 for
+// days without commits and 1-4 by quartile otherwise. Cells after the last
+// day of the calendar have a zero Date.
+type ContributionDay struct {
+	Date  time.Time
+	Count int
+	Level int
+}
+
+// ContributionCalendar is a year of daily commit counts laid out like
+// GitHub's: one column per week from Sunday to Saturday, the last column
+// holding the current week
+type ContributionCalendar struct {
+	Weeks [CalendarWeeks][7]ContributionDay
+	Total int
+	// Quartiles are the highest counts of levels 1, 2 and 3
+	Quartiles [3]int
+}
+
+// PunchCard counts commits by weekday, Sunday first, and hour of day
+type PunchCard [7][24]int
+
+// WorkingWindow is a block of consecutive weekdays and hours of a punch card.
+// Hours run from StartHour up to EndHour, past midnight when EndHour is not
+// after StartHour.
+type WorkingWindow struct {
+	FirstDay  time.Weekday
+	LastDay   time.Weekday
+	StartHour int
+	EndHour   int
+	Commits   int
+	// Share is the percentage of the punch card's commits inside the window
+	Share float64
+}
+
+// PeriodCommits is the number of commits in the month or year beginning at
+// Start
+type PeriodCommits struct {
+	Start   time.Time
+	Commits int
+	// Change is the difference from the previous period, zero for the first
+	Change int
+	// MovingAverage is the mean of this and up to two previous periods
+	MovingAverage float64
+}
+
+// YearLanguages is the language mix of one year's commits
+type YearLanguages struct {
+	Year int
+	// Commits counts the commits to repositories with known languages
+	Commits int
+	// Languages lists the same languages in the same order every year, with
+	// "Other" last when there is one
+	Languages []LanguageStats
+}
+
+// LanguageTrend is the change of a language's share in percentage points
+type LanguageTrend struct {
+	Language string
+	Change   float64
+}
+
+// Trend compares statistics with an earlier snapshot taken at Since
+type Trend struct {
+	Since         time.Time
+	Commits       int
+	CurrentStreak int
+	LongestStreak int
+	// Languages lists the languages whose share changed, largest change first
+	Languages []LanguageTrend
 }
 
 // ProfileStats contains aggregated statistics about a GitHub profile
@@ -14,6 +130,7 @@ type ProfileStats struct {
 	Username           string
 	Languages          []LanguageStats
 	TotalBytes         int
+	TotalCommits       int
 	MostProductiveDay  string
 	MostProductiveHour string
 	AccountAge         string
@@ -21,9 +138,123 @@ type ProfileStats struct {
 	LongestStreak      int
 	WeeklyDistribution map[string]int
 	LastUpdated        time.Time
+	// PercentagePrecision is the number of decimals of DisplayPercentage
+	PercentagePrecision int
+	// Window is the period the statistics cover
+	Window TimeWindow
+	// Weighting names the language weighting behind the percentages
+	Weighting string
+	// Trend compares with a previous snapshot, nil without history
+	Trend *Trend
+	// Calendar is the contribution calendar of the last year
+	Calendar *ContributionCalendar
+	// PunchCard counts the commits with a known time of day
+	PunchCard PunchCard
+	// WorkingWindow is the densest block of the punch card, nil without
+	// commits with a known time of day
+	WorkingWindow *WorkingWindow
+	// MonthlyCommits and YearlyCommits run from the first commit to now,
+	// oldest first, including periods without commits
+	MonthlyCommits []PeriodCommits
+	YearlyCommits  []PeriodCommits
+	// LanguageEvolution is the language mix per year, oldest first, empty
+	// when commits aren't attributed to repositories
+	LanguageEvolution []YearLanguages
+}
+
+// YearTotals are the headline numbers of one calendar year
+type YearTotals struct {
+	Commits       int
+	ActiveDays    int
+	LongestStreak int
+	Repositories  int
+}
+
+// RepositoryActivity is the number of commits made to one repository
+type RepositoryActivity struct {
+	Name    string
+	Commits int
+}
+
+// YearReview summarizes one calendar year of activity and compares it with
+// the year before. Repository and language details need sources that know
+// the repository of each commit.
+type YearReview struct {
+	Username string
+	Year     int
+	YearTotals
+	Previous            YearTotals
+	BusiestMonth        time.Month
+	BusiestMonthCommits int
+	// BusiestWeekStart is the Monday of the week with the most commits
+	BusiestWeekStart   time.Time
+	BusiestWeekCommits int
+	LongestStreakStart time.Time
+	// NewLanguages are used this year for the first time, most used first
+	NewLanguages    []string
+	TopRepositories []RepositoryActivity
+	LastUpdated     time.Time
 }
 
+// MemberStats summarizes one member's contributions to an organization
+type MemberStats struct {
+	Login        string
+	Commits      int
+	Repositories int
+	// Languages estimates the member's share of each language, weighting the
+	// languages of every repository by the member's share of its commits
+	Languages  []LanguageStats
+	LastCommit time.Time
+}
+
+// OrganizationStats contains aggregate statistics about an organization or
+// team, with the members ranked by their contributions
+type OrganizationStats struct {
+	Summary      *ProfileStats
+	Repositories int
+	Members      []MemberStats
+}
+
+// Repository describes a repository and the languages it contains. PushedAt
+// is the time of the last push, zero when the source doesn't know it.
+type Repository struct {
+	Owner     string
+	Name      string
+	Languages map[string]int
+	PushedAt  time.Time
+}
+
+// FullName returns the repository name prefixed with its owner, or just the
+// name for repositories without an owner such as local clones
+func (r Repository) FullName() string {
+	if r.Owner == "" {
+		return r.Name
+	}
+	return r.Owner + "/" + r.Name
+}
+
+// CommitAttribution records why a commit was counted as the profile owner's work
+type CommitAttribution string
+
+const (
+	// AttributedToLogin means the commit author is linked to the user's account
+	AttributedToLogin CommitAttribution = "login"
+	// AttributedToEmail means the author email matches one of the user's addresses
+	AttributedToEmail CommitAttribution = "email"
+	// AttributedToCoAuthor means the user is credited in a Co-authored-by trailer
+	AttributedToCoAuthor CommitAttribution = "co-author"
+)
+
 // Commit represents a simplified commit structure
 type Commit struct {
-	Date time.Time
+	SHA         string
+	Date        time.Time
+	Attribution CommitAttribution
+	// Repository is the owner/name of the repository the commit was found
+	// in, and Author the login of its author, when the source knows them
+	Repository string
+	Author     string
+	// DateOnly marks commits whose time of day is unknown, such as those
+	// derived from daily contribution counts
+	DateOnly bool
 }
@@ -14,12 +14,34 @@ type UserProfile struct {
 // GitHubRepository defines the interface for GitHub data access
 type GitHubRepository interface {
 	GetUsername(ctx context.Context) (string, error)
-	GetUserProfile(ctx context.Context) (*UserProfile, error)
-	GetLanguageStats(ctx context.Context, username string) (map[string]int, error)
+	// GetUserProfile returns the profile of username, or of the account the
+	// credentials belong to when username is empty
+	GetUserProfile(ctx context.Context, username string) (*UserProfile, error)
+	// GetRepositories returns the analyzed repositories with their languages
+	GetRepositories(ctx context.Context, username string) ([]Repository, error)
 	GetAllCommits(ctx context.Context, username string) ([]Commit, error)
 }
 
+// OrganizationRepository defines data access for organization-wide insights.
+// An empty team means the whole organization.
+type OrganizationRepository interface {
+	GetOrganizationProfile(ctx context.Context, org string) (*UserProfile, error)
+	GetOrganizationMembers(ctx context.Context, org, team string) ([]string, error)
+	GetOrganizationRepositories(ctx context.Context, org, team string) ([]Repository, error)
+	// GetOrganizationCommits returns the commits on the default branches of
+	// repositories whose author is one of members, with Author set
+	GetOrganizationCommits(ctx context.Context, repositories []Repository, members []string) ([]Commit, error)
+}
+
 // FileRepository defines the interface for file operations
 type FileRepository interface {
 	UpdateReadme(content string) error
+	// UpdateSection replaces the content between the named section markers
+	UpdateSection(section, content string) error
+	// SaveSnapshot appends stats to the snapshot history
+	SaveSnapshot(stats *ProfileStats) error
+	// LoadSnapshots returns every stored snapshot, oldest first
+	LoadSnapshots() ([]ProfileStats, error)
+	// WriteFile replaces the file at path with content
+	WriteFile(path, content string) error
 }
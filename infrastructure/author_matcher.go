@@ -0,0 +1,79 @@
+package infrastructure
+
+import (
+	"regexp"
+	"strings"
+
+	"GitInsights/domain"
+)
+
+// coAuthorTrailer matches "Co-authored-by: Name <email>" lines in commit messages
+var coAuthorTrailer = regexp.MustCompile(`(?im)^co-authored-by:.*<([^>]+)>\s*$`)
+
+// AuthorMatcher decides whether a commit belongs to the profile owner
+type AuthorMatcher struct {
+	login  string
+	emails map[string]bool
+}
+
+// NewAuthorMatcher creates a matcher for the given login and email aliases
+func NewAuthorMatcher(login string, emails []string) *AuthorMatcher {
+	emailSet := make(map[string]bool)
+	for _, email := range emails {
+		trimmed := strings.ToLower(strings.TrimSpace(email))
+		if trimmed != "" {
+			emailSet[trimmed] = true
+		}
+	}
+
+	return &AuthorMatcher{
+		login:  strings.ToLower(login),
+		emails: emailSet,
+	}
+}
+
+// Match reports whether a commit with the given author login, author email and
+// message is attributed to the user, and why
+func (m *AuthorMatcher) Match(authorLogin, authorEmail, message string) (domain.CommitAttribution, bool) {
+	if m.login != "" && strings.ToLower(authorLogin) == m.login {
+		return domain.AttributedToLogin, true
+	}
+
+	if m.isOwnEmail(authorEmail) {
+		return domain.AttributedToEmail, true
+	}
+
+	for _, trailer := range coAuthorTrailer.FindAllStringSubmatch(message, -1) {
+		if m.isOwnEmail(trailer[1]) {
+			return domain.AttributedToCoAuthor, true
+		}
+	}
+
+	return "", false
+}
+
+// isOwnEmail checks the configured aliases and the GitHub noreply addresses
+// (login@users.noreply.github.com and ID+login@users.noreply.github.com)
+func (m *AuthorMatcher) isOwnEmail(email string) bool {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return false
+	}
+
+	if m.emails[email] {
+		return true
+	}
+
+	if m.login == "" {
+		return false
+	}
+
+	local, found := strings.CutSuffix(email, "@users.noreply.github.com")
+	if !found {
+		return false
+	}
+	if _, name, ok := strings.Cut(local, "+"); ok {
+		local = name
+	}
+	return local == m.login
+}
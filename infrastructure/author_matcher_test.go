@@ -0,0 +1,41 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"GitInsights/domain"
+)
+
+func TestAuthorMatcher(t *testing.T) {
+	matcher := NewAuthorMatcher("Octocat", []string{"octo@work.example.com", " "})
+
+	tests := []struct {
+		name        string
+		login       string
+		email       string
+		message     string
+		attribution domain.CommitAttribution
+		matched     bool
+	}{
+		{"login", "octocat", "someone@example.com", "fix", domain.AttributedToLogin, true},
+		{"email alias", "", "OCTO@work.example.com", "fix", domain.AttributedToEmail, true},
+		{"noreply email", "", "583231+octocat@users.noreply.github.com", "fix", domain.AttributedToEmail, true},
+		{"legacy noreply email", "", "octocat@users.noreply.github.com", "fix", domain.AttributedToEmail, true},
+		{"co-author", "hubot", "hubot@example.com", "Pair on parser\n\nCo-authored-by: Octo Cat <octo@work.example.com>", domain.AttributedToCoAuthor, true},
+		{"other co-author", "hubot", "hubot@example.com", "Pair on parser\n\nCo-authored-by: Someone <someone@example.com>", "", false},
+		{"other author", "hubot", "hubot@example.com", "Mention octo@work.example.com", "", false},
+		{"other noreply", "", "1+hubot@users.noreply.github.com", "fix", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attribution, matched := matcher.Match(tt.login, tt.email, tt.message)
+			if matched != tt.matched {
+				t.Fatalf("Expected matched=%v, got: %v", tt.matched, matched)
+			}
+			if attribution != tt.attribution {
+				t.Errorf("Expected attribution %q, got: %q", tt.attribution, attribution)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package infrastructure
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"GitInsights/domain"
+)
+
+// commitStateVersion is bumped whenever the stored format or the meaning of
+// the stored commits changes, invalidating older state files
+const commitStateVersion = 2
+
+// CommitStateStore persists the commits fetched for each repository, so later
+// runs only need to fetch what was pushed since
+type CommitStateStore struct {
+	dir string
+}
+
+// commitState is everything remembered about one user on one GitHub host
+type commitState struct {
+	Version      int                         `json:"version"`
+	Fingerprint  string                      `json:"fingerprint"`
+	Repositories map[string]*repositoryState `json:"repositories"`
+}
+
+// repositoryState is the newest commit seen on a repository's default branch
+// and the user's commits up to it
+type repositoryState struct {
+	NewestSHA  string          `json:"newest_sha"`
+	NewestDate time.Time       `json:"newest_date"`
+	FetchedAt  time.Time       `json:"fetched_at"`
+	Commits    []domain.Commit `json:"commits"`
+}
+
+// NewCommitStateStore creates a store keeping its files in dir
+func NewCommitStateStore(dir string) *CommitStateStore {
+	return &CommitStateStore{
+		dir: dir,
+	}
+}
+
+// stateFingerprint identifies the settings that decide which commits are
+// stored; state recorded under different settings is discarded
+func stateFingerprint(host, username string, authorEmails []string) string {
+	emails := make([]string, 0, len(authorEmails))
+	for _, email := range authorEmails {
+		emails = append(emails, strings.ToLower(strings.TrimSpace(email)))
+	}
+	sort.Strings(emails)
+	return host + "|" + strings.ToLower(username) + "|" + strings.Join(emails, ",")
+}
+
+// path returns the state file for a fingerprint
+func (s *CommitStateStore) path(fingerprint string) string {
+	sum := sha256.Sum256([]byte(fingerprint))
+	return filepath.Join(s.dir, "commits-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// Load returns the stored state for fingerprint, or an empty state when
+// there is none or it was recorded under different settings
+func (s *CommitStateStore) Load(fingerprint string) *commitState {
+	empty := &commitState{
+		Version:      commitStateVersion,
+		Fingerprint:  fingerprint,
+		Repositories: make(map[string]*repositoryState),
+	}
+
+	data, err := os.ReadFile(s.path(fingerprint))
+	if err != nil {
+		return empty
+	}
+
+	var state commitState
+	if err := json.Unmarshal(data, &state); err != nil ||
+		state.Version != commitStateVersion ||
+		state.Fingerprint != fingerprint ||
+		state.Repositories == nil {
+		return empty
+	}
+	return &state
+}
+
+// Save writes the state atomically
+func (s *CommitStateStore) Save(state *commitState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode commit state: %w", err)
+	}
+
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(s.dir, "commits-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write commit state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write commit state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write commit state: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path(state.Fingerprint)); err != nil {
+		return fmt.Errorf("failed to write commit state: %w", err)
+	}
+	return nil
+}
@@ -5,22 +5,44 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 )
 
 // FileManager implements domain.FileRepository
 type FileManager struct {
-	filePath string
+	filePath     string
+	snapshotPath string
+}
+
+// FileManagerOption configures optional behaviour of FileManager
+type FileManagerOption func(*FileManager)
+
+// WithSnapshotFile keeps the snapshot history in the JSON-lines file at path
+func WithSnapshotFile(path string) FileManagerOption {
+	return func(f *FileManager) {
+		f.snapshotPath = path
+	}
 }
 
 // NewFileManager creates a new file manager
-func NewFileManager(filePath string) *FileManager {
-	return &FileManager{
+func NewFileManager(filePath string, opts ...FileManagerOption) *FileManager {
+	f := &FileManager{
 		filePath: filePath,
 	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
 }
 
-// UpdateReadme updates the README.md file with new content
+// UpdateReadme replaces the GitInsights section of the file with content
 func (f *FileManager) UpdateReadme(content string) error {
+	return f.UpdateSection("GitInsights", content)
+}
+
+// UpdateSection replaces the named marker section of the file, markers
+// included, with content
+func (f *FileManager) UpdateSection(section, content string) (err error) {
 	file, err := os.OpenFile(f.filePath, os.O_RDWR, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to open file: %w", err)
@@ -37,15 +59,15 @@ func (f *FileManager) UpdateReadme(content string) error {
 		return fmt.Errorf("failed to read file: %w", err)
 	}
 
-	// Find and replace the GitInsights section
-	startMarker := []byte("<!--START_SECTION:GitInsights-->")
-	endMarker := []byte("<!--END_SECTION:GitInsights-->")
+	// Find and replace the section
+	startMarker := []byte("<!--START_SECTION:" + section + "-->")
+	endMarker := []byte("<!--END_SECTION:" + section + "-->")
 
 	startIdx := bytes.Index(data, startMarker)
 	endIdx := bytes.Index(data, endMarker)
 
 	if startIdx == -1 || endIdx == -1 {
-		return fmt.Errorf("GitInsights section markers not found in file")
+		return fmt.Errorf("%s section markers not found in file", section)
 	}
 
 	// Build new content
@@ -63,3 +85,17 @@ func (f *FileManager) UpdateReadme(content string) error {
 
 	return nil
 }
+
+// WriteFile replaces the file at path with content, creating its directory
+// when needed
+func (f *FileManager) WriteFile(path, content string) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
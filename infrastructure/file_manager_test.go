@@ -89,3 +89,57 @@ No markers here
 		t.Error("Expected error for missing markers, got nil")
 	}
 }
+
+func TestFileManagerUpdateSection(t *testing.T) {
+	content := `# Test README
+
+<!--START_SECTION:GitInsights-->
+Profile
+<!--END_SECTION:GitInsights-->
+
+<!--START_SECTION:GitInsights-YearReview-->
+Old review
+<!--END_SECTION:GitInsights-YearReview-->
+`
+
+	path := t.TempDir() + "/README.md"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	fm := NewFileManager(path)
+	err := fm.UpdateSection("GitInsights-YearReview", `<!--START_SECTION:GitInsights-YearReview-->
+New review
+<!--END_SECTION:GitInsights-YearReview-->`)
+	if err != nil {
+		t.Fatalf("UpdateSection failed: %v", err)
+	}
+
+	updatedData, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read updated file: %v", err)
+	}
+	if !bytes.Contains(updatedData, []byte("New review")) || bytes.Contains(updatedData, []byte("Old review")) {
+		t.Error("Expected the year review section to be replaced")
+	}
+	if !bytes.Contains(updatedData, []byte("Profile")) {
+		t.Error("Expected the profile section to be left alone")
+	}
+}
+
+func TestFileManagerWriteFile(t *testing.T) {
+	path := t.TempDir() + "/assets/calendar.svg"
+
+	fm := NewFileManager(t.TempDir() + "/README.md")
+	if err := fm.WriteFile(path, "<svg></svg>\n"); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read written file: %v", err)
+	}
+	if string(data) != "<svg></svg>\n" {
+		t.Errorf("Expected the written content, got: %q", data)
+	}
+}
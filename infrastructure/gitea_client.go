@@ -0,0 +1,274 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"GitInsights/domain"
+)
+
+// giteaPageSize is the default maximum page size of Gitea and Forgejo instances
+const giteaPageSize = 50
+
+// GiteaClient implements domain.GitHubRepository for Gitea and Forgejo
+// instances (including Codeberg) through the REST API v1
+type GiteaClient struct {
+	api          *restClient
+	includeForks bool
+	authorEmails []string
+}
+
+type giteaUser struct {
+	Login   string    `json:"login"`
+	Email   string    `json:"email"`
+	Created time.Time `json:"created"`
+}
+
+type giteaRepository struct {
+	Name      string    `json:"name"`
+	FullName  string    `json:"full_name"`
+	Fork      bool      `json:"fork"`
+	Empty     bool      `json:"empty"`
+	Owner     giteaUser `json:"owner"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type giteaCommit struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Message string `json:"message"`
+		Author  struct {
+			Email string    `json:"email"`
+			Date  time.Time `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+	Author *giteaUser `json:"author"`
+}
+
+// NewGiteaClient creates a client for the Gitea or Forgejo instance at baseURL (e.g. https://codeberg.org)
+func NewGiteaClient(baseURL, token string, includeForks bool, authorEmails []string) *GiteaClient {
+	return &GiteaClient{
+		api: newRestClient(baseURL+"/api/v1", func(req *http.Request) {
+			if token != "" {
+				req.Header.Set("Authorization", "token "+token)
+			}
+		}),
+		includeForks: includeForks,
+		authorEmails: authorEmails,
+	}
+}
+
+// currentUser retrieves the user the token belongs to
+func (g *GiteaClient) currentUser(ctx context.Context) (*giteaUser, error) {
+	var user giteaUser
+	if _, err := g.api.getJSON(ctx, "/user", nil, &user); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.Login == "" {
+		return nil, fmt.Errorf("user login is empty")
+	}
+
+	return &user, nil
+}
+
+// getUser retrieves the given user, or the token owner when username is
+// empty. Gitea shows users their own private email on either endpoint.
+func (g *GiteaClient) getUser(ctx context.Context, username string) (*giteaUser, error) {
+	if username == "" {
+		return g.currentUser(ctx)
+	}
+
+	var user giteaUser
+	if _, err := g.api.getJSON(ctx, "/users/"+url.PathEscape(username), nil, &user); err != nil {
+		return nil, fmt.Errorf("failed to get user %s: %w", username, err)
+	}
+
+	if user.Login == "" {
+		return nil, fmt.Errorf("user login is empty")
+	}
+
+	return &user, nil
+}
+
+// GetUsername retrieves the authenticated user's username
+func (g *GiteaClient) GetUsername(ctx context.Context) (string, error) {
+	user, err := g.currentUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	return user.Login, nil
+}
+
+// GetUserProfile retrieves the profile of the given user, or of the
+// authenticated user when username is empty
+func (g *GiteaClient) GetUserProfile(ctx context.Context, username string) (*domain.UserProfile, error) {
+	user, err := g.getUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Created.IsZero() {
+		return nil, fmt.Errorf("user created is empty")
+	}
+
+	return &domain.UserProfile{
+		Username:  user.Login,
+		CreatedAt: user.Created,
+	}, nil
+}
+
+// hasMorePages reports whether a paginated Gitea response has a next page.
+// Newer versions send X-HasMore; older ones only return a short last page.
+func hasMorePages(resp *http.Response, pageLen int) bool {
+	if hasMore := resp.Header.Get("X-HasMore"); hasMore != "" {
+		return hasMore == "true"
+	}
+	return pageLen == giteaPageSize
+}
+
+// listRepositories returns the user's repositories across all pages
+func (g *GiteaClient) listRepositories(ctx context.Context, username string) ([]giteaRepository, error) {
+	var allRepos []giteaRepository
+	query := url.Values{"limit": {strconv.Itoa(giteaPageSize)}}
+
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+
+		var repos []giteaRepository
+		resp, err := g.api.getJSON(ctx, "/users/"+url.PathEscape(username)+"/repos", query, &repos)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories: %w", err)
+		}
+		allRepos = append(allRepos, repos...)
+
+		if len(repos) == 0 || !hasMorePages(resp, len(repos)) {
+			break
+		}
+	}
+
+	if g.includeForks {
+		return allRepos, nil
+	}
+
+	var filtered []giteaRepository
+	for _, repo := range allRepos {
+		if !repo.Fork {
+			filtered = append(filtered, repo)
+		}
+	}
+	return filtered, nil
+}
+
+// repoPath builds the API path of a repository sub-resource
+func (r giteaRepository) repoPath(resource string) string {
+	return "/repos/" + url.PathEscape(r.Owner.Login) + "/" + url.PathEscape(r.Name) + "/" + resource
+}
+
+// GetRepositories lists the analyzed repositories with their languages. The
+// last update stands in for the last push.
+func (g *GiteaClient) GetRepositories(ctx context.Context, username string) ([]domain.Repository, error) {
+	repos, err := g.listRepositories(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Analyzing languages across %d Gitea repositories...\n", len(repos))
+
+	var repositories []domain.Repository
+	for _, repo := range repos {
+		var languages map[string]int
+		if _, err := g.api.getJSON(ctx, repo.repoPath("languages"), nil, &languages); err != nil {
+			return nil, fmt.Errorf("failed to get languages for %s: %w", repo.FullName, err)
+		}
+
+		repositories = append(repositories, domain.Repository{
+			Owner:     repo.Owner.Login,
+			Name:      repo.Name,
+			Languages: languages,
+			PushedAt:  repo.UpdatedAt,
+		})
+	}
+
+	return repositories, nil
+}
+
+// GetAllCommits retrieves the user's commits across all repositories
+func (g *GiteaClient) GetAllCommits(ctx context.Context, username string) ([]domain.Commit, error) {
+	repos, err := g.listRepositories(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := g.authorEmails
+	if user, err := g.getUser(ctx, username); err == nil {
+		emails = append([]string{user.Email}, emails...)
+	}
+	matcher := NewAuthorMatcher(username, emails)
+
+	log.Printf("Fetching commits from %d Gitea repositories...\n", len(repos))
+
+	var allCommits []domain.Commit
+	for _, repo := range repos {
+		if repo.Empty {
+			continue
+		}
+
+		query := url.Values{
+			"limit":        {strconv.Itoa(giteaPageSize)},
+			"stat":         {"false"},
+			"verification": {"false"},
+			"files":        {"false"},
+		}
+
+		repoCommitCount := 0
+		for page := 1; ; page++ {
+			query.Set("page", strconv.Itoa(page))
+
+			var commits []giteaCommit
+			resp, err := g.api.getJSON(ctx, repo.repoPath("commits"), query, &commits)
+			if resp != nil && resp.StatusCode == http.StatusConflict {
+				// Gitea answers 409 for repositories without commits
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to list commits for %s: %w", repo.FullName, err)
+			}
+
+			for _, commit := range commits {
+				authorLogin := ""
+				if commit.Author != nil {
+					authorLogin = commit.Author.Login
+				}
+
+				attribution, ok := matcher.Match(authorLogin, commit.Commit.Author.Email, commit.Commit.Message)
+				if !ok {
+					continue
+				}
+				allCommits = append(allCommits, domain.Commit{
+					SHA:         commit.SHA,
+					Date:        commit.Commit.Author.Date,
+					Attribution: attribution,
+					Repository:  repo.Owner.Login + "/" + repo.Name,
+				})
+				repoCommitCount++
+			}
+
+			if len(commits) == 0 || !hasMorePages(resp, len(commits)) {
+				break
+			}
+		}
+
+		if repoCommitCount > 0 {
+			log.Printf("  ✓ %s: %d commits\n", repo.FullName, repoCommitCount)
+		}
+	}
+
+	log.Printf("Total commits analyzed: %d\n", len(allCommits))
+	return allCommits, nil
+}
@@ -0,0 +1,191 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newGiteaTestServer serves a user with more repositories than fit on one
+// page, one fork and an empty repository
+func newGiteaTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	writeJSON := func(w http.ResponseWriter, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}
+
+	owner := map[string]string{"login": "forgejo-fan"}
+	var repos []map[string]interface{}
+	for i := 0; i < giteaPageSize+2; i++ {
+		repos = append(repos, map[string]interface{}{
+			"name":      fmt.Sprintf("repo%d", i),
+			"full_name": fmt.Sprintf("forgejo-fan/repo%d", i),
+			"owner":     owner,
+			"fork":      i == 1,
+			"empty":     i > 1,
+		})
+	}
+
+	mux := http.NewServeMux()
+	// Users see their own private email on both endpoints
+	user := func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{
+			"login":   "forgejo-fan",
+			"email":   "fan@example.org",
+			"created": "2021-05-04T12:00:00+02:00",
+		})
+	}
+	mux.HandleFunc("/api/v1/user", user)
+	mux.HandleFunc("/api/v1/users/forgejo-fan", user)
+	mux.HandleFunc("/api/v1/users/forgejo-fan/repos", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		start := (page - 1) * giteaPageSize
+		end := start + giteaPageSize
+		if end >= len(repos) {
+			end = len(repos)
+			w.Header().Set("X-HasMore", "false")
+		} else {
+			w.Header().Set("X-HasMore", "true")
+		}
+		writeJSON(w, repos[start:end])
+	})
+	mux.HandleFunc("/api/v1/repos/forgejo-fan/repo0/languages", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]int{"Go": 1200, "Makefile": 80})
+	})
+	mux.HandleFunc("/api/v1/repos/forgejo-fan/repo0/commits", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]interface{}{
+			{
+				"sha":    "c1",
+				"commit": map[string]interface{}{"message": "Mine", "author": map[string]string{"email": "private@example.org", "date": "2023-11-13T09:00:00+01:00"}},
+				"author": map[string]string{"login": "forgejo-fan"},
+			},
+			{
+				"sha":    "c2",
+				"commit": map[string]interface{}{"message": "Also mine", "author": map[string]string{"email": "fan@example.org", "date": "2023-11-14T09:00:00+01:00"}},
+			},
+			{
+				"sha":    "c3",
+				"commit": map[string]interface{}{"message": "Not mine", "author": map[string]string{"email": "other@example.org", "date": "2023-11-14T10:00:00+01:00"}},
+				"author": map[string]string{"login": "other"},
+			},
+		})
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		// Every other repository is empty or has no languages
+		if strings.HasSuffix(r.URL.Path, "/languages") {
+			writeJSON(w, map[string]int{})
+			return
+		}
+		http.Error(w, "Git Repository is empty.", http.StatusConflict)
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGiteaClientProfile(t *testing.T) {
+	server := newGiteaTestServer(t)
+	client := NewGiteaClient(server.URL, "secret", false, nil)
+
+	profile, err := client.GetUserProfile(context.Background(), "")
+	if err != nil {
+		t.Fatalf("GetUserProfile failed: %v", err)
+	}
+
+	if profile.Username != "forgejo-fan" {
+		t.Errorf("Expected username 'forgejo-fan', got: %s", profile.Username)
+	}
+	if profile.CreatedAt.Year() != 2021 {
+		t.Errorf("Expected account created in 2021, got: %s", profile.CreatedAt)
+	}
+}
+
+func TestGiteaClientProfileOfGivenUser(t *testing.T) {
+	// Only the public profile is served, as to a token of someone else
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/users/codeberg-fan" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"login": "codeberg-fan", "created": "2019-02-01T10:00:00Z"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	profile, err := NewGiteaClient(server.URL, "other", false, nil).GetUserProfile(context.Background(), "codeberg-fan")
+	if err != nil {
+		t.Fatalf("GetUserProfile failed: %v", err)
+	}
+	if profile.Username != "codeberg-fan" || profile.CreatedAt.Year() != 2019 {
+		t.Errorf("Expected the public profile of codeberg-fan, got: %+v", profile)
+	}
+}
+
+func TestGiteaClientListRepositoriesPaginates(t *testing.T) {
+	server := newGiteaTestServer(t)
+	ctx := context.Background()
+
+	repos, err := NewGiteaClient(server.URL, "secret", true, nil).listRepositories(ctx, "forgejo-fan")
+	if err != nil {
+		t.Fatalf("listRepositories failed: %v", err)
+	}
+	if len(repos) != giteaPageSize+2 {
+		t.Errorf("Expected %d repositories across pages, got: %d", giteaPageSize+2, len(repos))
+	}
+
+	repos, err = NewGiteaClient(server.URL, "secret", false, nil).listRepositories(ctx, "forgejo-fan")
+	if err != nil {
+		t.Fatalf("listRepositories failed: %v", err)
+	}
+	if len(repos) != giteaPageSize+1 {
+		t.Errorf("Expected the fork to be excluded, got: %d repositories", len(repos))
+	}
+}
+
+func TestGiteaClientLanguageStats(t *testing.T) {
+	server := newGiteaTestServer(t)
+	client := NewGiteaClient(server.URL, "secret", false, nil)
+
+	repos, err := client.GetRepositories(context.Background(), "forgejo-fan")
+	if err != nil {
+		t.Fatalf("GetRepositories failed: %v", err)
+	}
+	languages := sumLanguages(repos)
+
+	if languages["Go"] != 1200 || languages["Makefile"] != 80 {
+		t.Errorf("Expected Go=1200 and Makefile=80, got: %v", languages)
+	}
+}
+
+func TestGiteaClientCommits(t *testing.T) {
+	server := newGiteaTestServer(t)
+	client := NewGiteaClient(server.URL, "secret", false, nil)
+
+	commits, err := client.GetAllCommits(context.Background(), "forgejo-fan")
+	if err != nil {
+		t.Fatalf("GetAllCommits failed: %v", err)
+	}
+
+	if len(commits) != 2 {
+		t.Fatalf("Expected 2 attributed commits, got: %d", len(commits))
+	}
+	if commits[0].SHA != "c1" || commits[1].SHA != "c2" {
+		t.Errorf("Expected commits c1 and c2, got: %s and %s", commits[0].SHA, commits[1].SHA)
+	}
+}
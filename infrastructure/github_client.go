@@ -2,9 +2,17 @@ package infrastructure
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"GitInsights/domain"
 
@@ -14,20 +22,146 @@ import (
 
 // GitHubClient implements domain.GitHubRepository
 type GitHubClient struct {
-	client       *github.Client
-	includeForks bool
+	client                *github.Client
+	includeForks          bool
+	authorEmails          []string
+	maxConcurrentRequests int
+	schedulers            *RequestSchedulers
+	cacheDir              string
+	cacheTTL              time.Duration
+	stateStore            *CommitStateStore
+	fullRefresh           bool
+	affiliation           string
+	filterConfig          RepositoryFilterConfig
+	repoFilter            *RepositoryFilter
+	deepLanguages         bool
+}
+
+// GitHubClientOption configures optional behaviour of GitHubClient
+type GitHubClientOption func(*GitHubClient)
+
+// WithAuthorEmails adds email aliases whose commits are attributed to the user
+func WithAuthorEmails(emails []string) GitHubClientOption {
+	return func(g *GitHubClient) {
+		g.authorEmails = emails
+	}
+}
+
+// WithMaxConcurrentRequests caps how many GitHub API requests run at once
+func WithMaxConcurrentRequests(n int) GitHubClientOption {
+	return func(g *GitHubClient) {
+		g.maxConcurrentRequests = n
+	}
+}
+
+// WithRequestSchedulers sends requests through the scheduler schedulers
+// keeps for the host and token, shared with every other client using it. It
+// takes precedence over WithMaxConcurrentRequests.
+func WithRequestSchedulers(schedulers *RequestSchedulers) GitHubClientOption {
+	return func(g *GitHubClient) {
+		g.schedulers = schedulers
+	}
+}
+
+// WithCache stores REST responses under dir and revalidates them with
+// conditional requests once they are older than ttl
+func WithCache(dir string, ttl time.Duration) GitHubClientOption {
+	return func(g *GitHubClient) {
+		g.cacheDir = dir
+		g.cacheTTL = ttl
+	}
+}
+
+// WithCommitState remembers fetched commits in store so later runs only fetch
+// new ones. fullRefresh ignores what was stored and rebuilds it.
+func WithCommitState(store *CommitStateStore, fullRefresh bool) GitHubClientOption {
+	return func(g *GitHubClient) {
+		g.stateStore = store
+		g.fullRefresh = fullRefresh
+	}
+}
+
+// WithAffiliation also analyzes the repositories the token owner can access
+// through the given affiliations ("owner", "collaborator",
+// "organization_member"), not only the ones they own
+func WithAffiliation(affiliations []string) GitHubClientOption {
+	return func(g *GitHubClient) {
+		g.affiliation = strings.Join(affiliations, ",")
+	}
+}
+
+// WithRepositoryFilter narrows the analyzed repositories by name patterns,
+// topics and repository flags
+func WithRepositoryFilter(cfg RepositoryFilterConfig) GitHubClientOption {
+	return func(g *GitHubClient) {
+		g.filterConfig = cfg
+	}
+}
+
+// WithDeepLanguages recomputes each repository's languages from its file tree
+// instead of using GitHub's totals, honouring linguist-vendored,
+// linguist-generated, linguist-documentation and linguist-language overrides
+func WithDeepLanguages() GitHubClientOption {
+	return func(g *GitHubClient) {
+		g.deepLanguages = true
+	}
 }
 
 // NewGitHubClient creates a new GitHub client
-func NewGitHubClient(token string, includeForks bool) *GitHubClient {
-	ctx := context.Background()
-	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
-	tc := oauth2.NewClient(ctx, ts)
+func NewGitHubClient(token string, includeForks bool, opts ...GitHubClientOption) *GitHubClient {
+	g := newGitHubClient(includeForks, opts)
+	g.client = github.NewClient(g.httpClient(githubAPIHost, token))
+	return g
+}
+
+// NewGitHubEnterpriseClient creates a client for a GitHub Enterprise Server
+// instance at baseURL (e.g. https://github.example.com)
+func NewGitHubEnterpriseClient(baseURL, token string, includeForks bool, opts ...GitHubClientOption) (*GitHubClient, error) {
+	g := newGitHubClient(includeForks, opts)
+	client, err := github.NewEnterpriseClient(baseURL, baseURL, g.httpClient(hostOf(baseURL), token))
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub Enterprise URL: %w", err)
+	}
+	g.client = client
+	return g, nil
+}
 
-	return &GitHubClient{
-		client:       github.NewClient(tc),
+// newGitHubClient applies the options shared by both constructors
+func newGitHubClient(includeForks bool, opts []GitHubClientOption) *GitHubClient {
+	g := &GitHubClient{
 		includeForks: includeForks,
 	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	g.repoFilter = NewRepositoryFilter(g.filterConfig, includeForks)
+	return g
+}
+
+// httpClient builds the HTTP client for the API at host, with the response
+// cache in front of the scheduler so fresh cache hits don't take a request
+// slot
+func (g *GitHubClient) httpClient(host, token string) *http.Client {
+	var transport http.RoundTripper
+	if g.schedulers != nil {
+		transport = g.schedulers.scheduler(host, token)
+	} else {
+		transport = newGitHubTransport(token, g.maxConcurrentRequests)
+	}
+	if g.cacheDir != "" {
+		// Keep responses seen with different tokens apart
+		sum := sha256.Sum256([]byte(token))
+		dir := filepath.Join(g.cacheDir, hex.EncodeToString(sum[:8]))
+		transport = NewHTTPCache(transport, dir, g.cacheTTL)
+	}
+	return &http.Client{Transport: transport}
+}
+
+// newGitHubTransport builds the authenticated transport shared by the REST
+// and GraphQL clients. Every request goes through a RequestScheduler.
+func newGitHubTransport(token string, maxConcurrentRequests int) *RequestScheduler {
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	return NewRequestScheduler(&oauth2.Transport{Source: ts}, maxConcurrentRequests)
 }
 
 // GetUsername retrieves the authenticated user's username
@@ -44,9 +178,10 @@ func (g *GitHubClient) GetUsername(ctx context.Context) (string, error) {
 	return *user.Login, nil
 }
 
-// GetUserProfile retrieves the authenticated user's profile information
-func (g *GitHubClient) GetUserProfile(ctx context.Context) (*domain.UserProfile, error) {
-	user, _, err := g.client.Users.Get(ctx, "")
+// GetUserProfile retrieves the profile of the given user, or of the
+// authenticated user when username is empty
+func (g *GitHubClient) GetUserProfile(ctx context.Context, username string) (*domain.UserProfile, error) {
+	user, _, err := g.client.Users.Get(ctx, username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
@@ -65,24 +200,16 @@ func (g *GitHubClient) GetUserProfile(ctx context.Context) (*domain.UserProfile,
 	}, nil
 }
 
-// filterRepositories filters out forks if includeForks is false
+// filterRepositories applies the repository filter chain
 func (g *GitHubClient) filterRepositories(repos []*github.Repository) []*github.Repository {
-	if g.includeForks {
-		return repos
-	}
-
-	var filtered []*github.Repository
-	for _, repo := range repos {
-		if repo.Fork == nil || !*repo.Fork {
-			filtered = append(filtered, repo)
-		}
-	}
-	return filtered
+	return g.repoFilter.Apply(repos)
 }
 
-// GetLanguageStats aggregates language statistics across all repositories
-func (g *GitHubClient) GetLanguageStats(ctx context.Context, username string) (map[string]int, error) {
-	// Get all repositories with pagination
+// listRepositories retrieves the user's repositories with pagination and
+// applies the repository filters. With an affiliation, the token owner's
+// repositories are listed through /user/repos, which includes the
+// organization and collaborator repositories they have access to.
+func (g *GitHubClient) listRepositories(ctx context.Context, username string) ([]*github.Repository, error) {
 	var allRepos []*github.Repository
 	opts := &github.RepositoryListOptions{
 		ListOptions: github.ListOptions{
@@ -90,8 +217,22 @@ func (g *GitHubClient) GetLanguageStats(ctx context.Context, username string) (m
 		},
 	}
 
+	user := username
+	if g.affiliation != "" {
+		owner, err := g.GetUsername(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(owner, username) {
+			user = ""
+			opts.Affiliation = g.affiliation
+		} else {
+			log.Printf("Warning: affiliations only apply to the token owner, listing %s's own repositories\n", username)
+		}
+	}
+
 	for {
-		repos, resp, err := g.client.Repositories.List(ctx, username, opts)
+		repos, resp, err := g.client.Repositories.List(ctx, user, opts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to list repositories: %w", err)
 		}
@@ -103,131 +244,400 @@ func (g *GitHubClient) GetLanguageStats(ctx context.Context, username string) (m
 		opts.Page = resp.NextPage
 	}
 
-	// Filter out forks if needed
-	allRepos = g.filterRepositories(allRepos)
+	return g.filterRepositories(allRepos), nil
+}
 
-	log.Printf("Analyzing languages across %d repositories%s...\n",
-		len(allRepos),
-		func() string {
-			if !g.includeForks {
-				return " (excluding forks)"
-			}
-			return ""
-		}())
+// forEachRepository runs fn for every repository concurrently; the request
+// scheduler bounds how many API calls are actually in flight. The first
+// error is returned once all calls have finished.
+func (g *GitHubClient) forEachRepository(repos []*github.Repository, fn func(repo *github.Repository) error) error {
+	return runConcurrently(len(repos), func(i int) error {
+		return fn(repos[i])
+	})
+}
 
-	languageStats := make(map[string]int)
+// runConcurrently calls fn for 0..n-1 concurrently and returns the first
+// error once all calls have finished
+func runConcurrently(n int, fn func(i int) error) error {
+	var firstErr error
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 
-	// Fetch languages for each repository concurrently
-	for _, repo := range allRepos {
+	for i := 0; i < n; i++ {
 		wg.Add(1)
-		go func(repoName string) {
+		go func(i int) {
 			defer wg.Done()
-
-			languages, _, err := g.client.Repositories.ListLanguages(ctx, username, repoName)
-			if err != nil {
-				log.Printf("Error fetching languages for %s: %v\n", repoName, err)
-				return
-			}
-
-			mu.Lock()
-			for lang, bytes := range languages {
-				languageStats[lang] += bytes
+			if err := fn(i); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
 			}
-			mu.Unlock()
-		}(*repo.Name)
+		}(i)
 	}
 
 	wg.Wait()
-	return languageStats, nil
+	return firstErr
 }
 
-// GetAllCommits retrieves all commits across all repositories
-func (g *GitHubClient) GetAllCommits(ctx context.Context, username string) ([]domain.Commit, error) {
-	// Get all repositories with pagination
-	var allRepos []*github.Repository
-	repoOpts := &github.RepositoryListOptions{
-		ListOptions: github.ListOptions{
-			PerPage: 100,
-		},
+// forksSuffix describes the fork filter in log messages
+func (g *GitHubClient) forksSuffix() string {
+	if !g.includeForks {
+		return " (excluding forks)"
 	}
+	return ""
+}
 
-	for {
-		repos, resp, err := g.client.Repositories.List(ctx, username, repoOpts)
+// GetRepositories lists the analyzed repositories with their languages
+func (g *GitHubClient) GetRepositories(ctx context.Context, username string) ([]domain.Repository, error) {
+	allRepos, err := g.listRepositories(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Analyzing languages across %d repositories%s...\n", len(allRepos), g.forksSuffix())
+
+	return g.withLanguages(ctx, allRepos)
+}
+
+// withLanguages fetches the languages of every repository concurrently.
+// Repositories that can't be read are logged and left out.
+func (g *GitHubClient) withLanguages(ctx context.Context, repos []*github.Repository) ([]domain.Repository, error) {
+	fetched := make([]*domain.Repository, len(repos))
+	err := runConcurrently(len(repos), func(i int) error {
+		languages, err := g.repositoryLanguages(ctx, repos[i])
 		if err != nil {
-			return nil, fmt.Errorf("failed to list repositories: %w", err)
+			if skipRepository(repos[i].GetFullName(), err) {
+				return nil
+			}
+			return err
 		}
-		allRepos = append(allRepos, repos...)
 
-		if resp.NextPage == 0 {
-			break
+		repository := newDomainRepository(repos[i], languages)
+		fetched[i] = &repository
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var repositories []domain.Repository
+	for _, repository := range fetched {
+		if repository != nil {
+			repositories = append(repositories, *repository)
 		}
-		repoOpts.Page = resp.NextPage
 	}
+	return repositories, nil
+}
 
-	// Filter out forks if needed
-	allRepos = g.filterRepositories(allRepos)
+// skipRepository reports whether err only concerns a single repository, and
+// logs it if so: access denied, for example by SAML enforcement (403), a
+// repository deleted during the run (404) or blocked for legal reasons (451).
+// Rate limits and server errors that outlast the retries are not skipped.
+func skipRepository(name string, err error) bool {
+	var errResp *github.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil {
+		return false
+	}
 
-	var allCommits []domain.Commit
+	switch errResp.Response.StatusCode {
+	case http.StatusForbidden:
+		if isRateLimited(errResp.Response) {
+			return false
+		}
+	case http.StatusNotFound, http.StatusUnavailableForLegalReasons:
+	default:
+		return false
+	}
+
+	log.Printf("  ⚠️  %s: skipped, %v\n", name, err)
+	return true
+}
+
+// newDomainRepository converts a GitHub repository and its languages
+func newDomainRepository(repo *github.Repository, languages map[string]int) domain.Repository {
+	repository := domain.Repository{
+		Owner:     repo.GetOwner().GetLogin(),
+		Name:      repo.GetName(),
+		Languages: languages,
+	}
+	if repo.PushedAt != nil {
+		repository.PushedAt = repo.PushedAt.Time
+	}
+	return repository
+}
+
+// GetAllCommits retrieves the user's commits across all repositories. A commit
+// counts when its author is linked to username, its author email is one of the
+// configured aliases, or the user is credited in a Co-authored-by trailer.
+// With a state store, only commits pushed since the previous run are fetched.
+func (g *GitHubClient) GetAllCommits(ctx context.Context, username string) ([]domain.Commit, error) {
+	allRepos, err := g.listRepositories(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	matcher := NewAuthorMatcher(username, g.authorEmails)
+
+	// Load what previous runs already fetched
+	var state *commitState
+	if g.stateStore != nil {
+		fingerprint := stateFingerprint(g.client.BaseURL.Host, username, g.authorEmails)
+		state = g.stateStore.Load(fingerprint)
+		if g.fullRefresh {
+			state.Repositories = make(map[string]*repositoryState)
+		}
+	}
+
+	repoStates := make(map[string]*repositoryState)
 	var mu sync.Mutex
-	var wg sync.WaitGroup
 
-	log.Printf("Fetching commits from %d repositories%s...\n",
-		len(allRepos),
-		func() string {
-			if !g.includeForks {
-				return " (excluding forks)"
-			}
-			return ""
-		}())
+	log.Printf("Fetching commits from %d repositories%s...\n", len(allRepos), g.forksSuffix())
 
 	// Fetch commits for each repository concurrently
-	for _, repo := range allRepos {
-		wg.Add(1)
-		go func(repoName string) {
-			defer wg.Done()
+	err = g.forEachRepository(allRepos, func(repo *github.Repository) error {
+		var previous *repositoryState
+		if state != nil {
+			previous = state.Repositories[repo.GetFullName()]
+		}
 
-			// Fetch all commits with pagination
-			opts := &github.CommitsListOptions{
-				ListOptions: github.ListOptions{
-					PerPage: 100, // Maximum allowed by GitHub API
-				},
+		repoState, err := g.repositoryCommits(ctx, repo, username, matcher, previous)
+		if err != nil {
+			if skipRepository(repo.GetFullName(), err) {
+				return nil
 			}
+			return err
+		}
 
-			repoCommitCount := 0
-			for {
-				commits, resp, err := g.client.Repositories.ListCommits(ctx, username, repoName, opts)
-				if err != nil {
-					log.Printf("Error fetching commits for %s: %v\n", repoName, err)
-					return
-				}
+		mu.Lock()
+		repoStates[repo.GetFullName()] = repoState
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-				mu.Lock()
-				for _, commit := range commits {
-					if commit.Commit != nil && commit.Commit.Author != nil && commit.Commit.Author.Date != nil {
-						allCommits = append(allCommits, domain.Commit{
-							Date: *commit.Commit.Author.Date,
-						})
-						repoCommitCount++
-					}
-				}
-				mu.Unlock()
+	// Combine repositories in a stable order
+	names := make([]string, 0, len(repoStates))
+	for name := range repoStates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-				// Check if there are more pages
-				if resp.NextPage == 0 {
-					break
+	attributions := make(map[domain.CommitAttribution]int)
+	var allCommits []domain.Commit
+	for _, name := range names {
+		for _, commit := range repoStates[name].Commits {
+			allCommits = append(allCommits, commit)
+			attributions[commit.Attribution]++
+		}
+	}
+
+	// Repositories that disappeared are dropped from the state
+	if state != nil {
+		state.Repositories = repoStates
+		if err := g.stateStore.Save(state); err != nil {
+			log.Printf("Warning: %v\n", err)
+		}
+	}
+
+	log.Printf("Total commits analyzed: %d (by login: %d, by email: %d, as co-author: %d)\n",
+		len(allCommits),
+		attributions[domain.AttributedToLogin],
+		attributions[domain.AttributedToEmail],
+		attributions[domain.AttributedToCoAuthor])
+	return allCommits, nil
+}
+
+// repositoryCommits returns the user's commits in a repository. When the
+// previous run's state is still valid, only newer commits are fetched: the
+// stored head must be an ancestor of the default branch, and listing with
+// Since must return exactly as many new commits as the comparison reports
+// (commits merged in from old branches keep their old dates and would be
+// missed). Otherwise the full history is fetched again.
+func (g *GitHubClient) repositoryCommits(ctx context.Context, repo *github.Repository, username string, matcher *AuthorMatcher, previous *repositoryState) (*repositoryState, error) {
+	owner, name := repo.GetOwner().GetLogin(), repo.GetName()
+	fetchedAt := time.Now()
+
+	// Nothing was pushed since the last run
+	if previous != nil && repo.PushedAt != nil && !repo.PushedAt.After(previous.FetchedAt) {
+		return previous, nil
+	}
+
+	// Other people's repositories can be huge, so only the user's own
+	// commits are requested and the history is always fetched in full
+	if !strings.EqualFold(owner, username) {
+		latest, err := g.listAuthoredCommits(ctx, owner, name, username, matcher)
+		if err != nil {
+			return nil, err
+		}
+		latest.FetchedAt = fetchedAt
+
+		if len(latest.Commits) > 0 {
+			log.Printf("  ✓ %s: %d commits\n", repo.GetFullName(), len(latest.Commits))
+		}
+		return latest, nil
+	}
+
+	if previous != nil && previous.NewestSHA != "" {
+		comparison, _, err := g.client.Repositories.CompareCommits(ctx, owner, name,
+			previous.NewestSHA, repo.GetDefaultBranch(), &github.ListOptions{PerPage: 1})
+		switch {
+		case err != nil:
+			log.Printf("  ↻ %s: stored history not found, refetching\n", name)
+		case comparison.GetStatus() == "identical":
+			previous.FetchedAt = fetchedAt
+			return previous, nil
+		case comparison.GetStatus() == "ahead":
+			latest, listed, err := g.listCommits(ctx, owner, name, previous.NewestDate, "", matcher)
+			if err != nil {
+				return nil, err
+			}
+
+			newCount := 0
+			for _, sha := range listed {
+				if sha != previous.NewestSHA {
+					newCount++
 				}
-				opts.Page = resp.NextPage
 			}
 
-			if repoCommitCount > 0 {
-				log.Printf("  ✓ %s: %d commits\n", repoName, repoCommitCount)
+			if newCount == comparison.GetAheadBy() {
+				merged := mergeRepositoryState(previous, latest, fetchedAt)
+				log.Printf("  ✓ %s: %d commits (%d new)\n", name, len(merged.Commits), len(merged.Commits)-len(previous.Commits))
+				return merged, nil
 			}
-		}(*repo.Name)
+			log.Printf("  ↻ %s: %d new commits predate the last run, refetching\n", name, comparison.GetAheadBy()-newCount)
+		default:
+			log.Printf("  ↻ %s: history was rewritten, refetching\n", name)
+		}
 	}
 
-	wg.Wait()
-	log.Printf("Total commits analyzed: %d\n", len(allCommits))
-	return allCommits, nil
+	latest, _, err := g.listCommits(ctx, owner, name, time.Time{}, "", matcher)
+	if err != nil {
+		return nil, err
+	}
+	latest.FetchedAt = fetchedAt
+
+	if len(latest.Commits) > 0 {
+		log.Printf("  ✓ %s: %d commits\n", name, len(latest.Commits))
+	}
+	return latest, nil
+}
+
+// listAuthoredCommits lets GitHub filter a repository's history by the
+// user's login and by each email alias. Commits where the user is only a
+// co-author can't be found this way.
+func (g *GitHubClient) listAuthoredCommits(ctx context.Context, owner, repoName, username string, matcher *AuthorMatcher) (*repositoryState, error) {
+	state := &repositoryState{}
+	seen := make(map[string]bool)
+
+	for _, author := range append([]string{username}, g.authorEmails...) {
+		latest, _, err := g.listCommits(ctx, owner, repoName, time.Time{}, author, matcher)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, commit := range latest.Commits {
+			if !seen[commit.SHA] {
+				seen[commit.SHA] = true
+				state.Commits = append(state.Commits, commit)
+			}
+		}
+	}
+
+	return state, nil
+}
+
+// mergeRepositoryState adds the commits of an incremental fetch to the stored ones
+func mergeRepositoryState(previous, latest *repositoryState, fetchedAt time.Time) *repositoryState {
+	known := make(map[string]bool, len(previous.Commits))
+	for _, commit := range previous.Commits {
+		known[commit.SHA] = true
+	}
+
+	merged := &repositoryState{
+		NewestSHA:  latest.NewestSHA,
+		NewestDate: latest.NewestDate,
+		FetchedAt:  fetchedAt,
+		Commits:    append([]domain.Commit{}, previous.Commits...),
+	}
+	for _, commit := range latest.Commits {
+		if !known[commit.SHA] {
+			merged.Commits = append(merged.Commits, commit)
+		}
+	}
+	return merged
+}
+
+// commitMatcher decides which listed commits are kept and why
+type commitMatcher interface {
+	Match(authorLogin, authorEmail, message string) (domain.CommitAttribution, bool)
+}
+
+// listCommits pages through the default branch of a repository, optionally
+// only from since on and only by author (a login or email). It returns the
+// matching commits with the newest listed commit, and the SHAs of every
+// listed commit.
+func (g *GitHubClient) listCommits(ctx context.Context, owner, repoName string, since time.Time, author string, matcher commitMatcher) (*repositoryState, []string, error) {
+	// Fetch all commits with pagination
+	opts := &github.CommitsListOptions{
+		Since:  since,
+		Author: author,
+		ListOptions: github.ListOptions{
+			PerPage: 100, // Maximum allowed by GitHub API
+		},
+	}
+
+	state := &repositoryState{}
+	var listed []string
+	for {
+		commits, resp, err := g.client.Repositories.ListCommits(ctx, owner, repoName, opts)
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			// GitHub answers 409 for empty repositories
+			return state, listed, nil
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch commits for %s: %w", repoName, err)
+		}
+
+		for _, commit := range commits {
+			// The first commit listed is the head of the branch
+			if state.NewestSHA == "" {
+				state.NewestSHA = commit.GetSHA()
+				state.NewestDate = commit.GetCommit().GetCommitter().GetDate()
+			}
+			listed = append(listed, commit.GetSHA())
+
+			if commit.Commit == nil || commit.Commit.Author == nil || commit.Commit.Author.Date == nil {
+				continue
+			}
+
+			attribution, ok := matcher.Match(
+				commit.GetAuthor().GetLogin(),
+				commit.Commit.Author.GetEmail(),
+				commit.Commit.GetMessage(),
+			)
+			if !ok {
+				continue
+			}
+
+			state.Commits = append(state.Commits, domain.Commit{
+				SHA:         commit.GetSHA(),
+				Date:        *commit.Commit.Author.Date,
+				Attribution: attribution,
+				Repository:  owner + "/" + repoName,
+				Author:      commit.GetAuthor().GetLogin(),
+			})
+		}
+
+		// Check if there are more pages
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return state, listed, nil
 }
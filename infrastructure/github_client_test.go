@@ -0,0 +1,385 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"GitInsights/domain"
+
+	"github.com/google/go-github/v38/github"
+)
+
+// fakeCommit is a commit on the fake repository's default branch
+type fakeCommit struct {
+	sha       string
+	author    string
+	committed time.Time
+}
+
+// fakeGitHub serves a single repository "octocat/app" whose history and push
+// date can be changed between runs, and counts the commit requests it gets
+type fakeGitHub struct {
+	mu           sync.Mutex
+	pushedAt     time.Time
+	history      []fakeCommit // newest first
+	compare      map[string]interface{}
+	listRequests int
+	lastSince    string
+}
+
+func (f *fakeGitHub) handler(t *testing.T) http.Handler {
+	writeJSON := func(w http.ResponseWriter, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/users/octocat/repos", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		writeJSON(w, []map[string]interface{}{{
+			"name":           "app",
+			"full_name":      "octocat/app",
+			"owner":          map[string]string{"login": "octocat"},
+			"default_branch": "main",
+			"pushed_at":      f.pushedAt.Format(time.RFC3339),
+		}})
+	})
+	mux.HandleFunc("/api/v3/repos/octocat/app/commits", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.listRequests++
+		f.lastSince = r.URL.Query().Get("since")
+
+		var since time.Time
+		if f.lastSince != "" {
+			since, _ = time.Parse(time.RFC3339, f.lastSince)
+		}
+
+		var commits []map[string]interface{}
+		for _, c := range f.history {
+			if c.committed.Before(since) {
+				continue
+			}
+			commits = append(commits, map[string]interface{}{
+				"sha":    c.sha,
+				"author": map[string]string{"login": c.author},
+				"commit": map[string]interface{}{
+					"message":   "change " + c.sha,
+					"author":    map[string]string{"email": c.author + "@example.com", "date": c.committed.Format(time.RFC3339)},
+					"committer": map[string]string{"date": c.committed.Format(time.RFC3339)},
+				},
+			})
+		}
+		writeJSON(w, commits)
+	})
+	mux.HandleFunc("/api/v3/repos/octocat/app/compare/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.compare == nil {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, f.compare)
+	})
+	return mux
+}
+
+func newIncrementalTestClient(t *testing.T, server *httptest.Server, stateDir string, fullRefresh bool) *GitHubClient {
+	t.Helper()
+
+	client, err := NewGitHubEnterpriseClient(server.URL, "token", false,
+		WithCommitState(NewCommitStateStore(stateDir), fullRefresh))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return client
+}
+
+func commitSHAs(t *testing.T, client *GitHubClient) string {
+	t.Helper()
+
+	commits, err := client.GetAllCommits(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("GetAllCommits failed: %v", err)
+	}
+
+	var shas []string
+	for _, commit := range commits {
+		shas = append(shas, commit.SHA)
+	}
+	sort.Strings(shas)
+	return strings.Join(shas, ",")
+}
+
+// sumLanguages adds up the languages of every repository
+func sumLanguages(repos []domain.Repository) map[string]int {
+	languages := make(map[string]int)
+	for _, repo := range repos {
+		for lang, bytes := range repo.Languages {
+			languages[lang] += bytes
+		}
+	}
+	return languages
+}
+
+func TestGitHubClientIncrementalCommits(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, 11, d, 12, 0, 0, 0, time.UTC) }
+	stateDir := t.TempDir()
+	fake := &fakeGitHub{
+		pushedAt: day(2),
+		history: []fakeCommit{
+			{sha: "c2", author: "octocat", committed: day(2)},
+			{sha: "x1", author: "hubot", committed: day(1)},
+			{sha: "c1", author: "octocat", committed: day(1)},
+		},
+	}
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	// First run fetches everything
+	if got := commitSHAs(t, newIncrementalTestClient(t, server, stateDir, false)); got != "c1,c2" {
+		t.Fatalf("Expected c1,c2 on first run, got: %s", got)
+	}
+
+	// Nothing pushed since: no commit requests at all
+	fake.listRequests = 0
+	if got := commitSHAs(t, newIncrementalTestClient(t, server, stateDir, false)); got != "c1,c2" {
+		t.Errorf("Expected stored c1,c2, got: %s", got)
+	}
+	if fake.listRequests != 0 {
+		t.Errorf("Expected no commit requests for an unchanged repository, got: %d", fake.listRequests)
+	}
+
+	// One new commit: only fetched since the stored head
+	fake.pushedAt = time.Now().Add(time.Hour)
+	fake.history = append([]fakeCommit{{sha: "c3", author: "octocat", committed: day(3)}}, fake.history...)
+	fake.compare = map[string]interface{}{"status": "ahead", "ahead_by": 1}
+	if got := commitSHAs(t, newIncrementalTestClient(t, server, stateDir, false)); got != "c1,c2,c3" {
+		t.Errorf("Expected c1,c2,c3 after incremental fetch, got: %s", got)
+	}
+	if fake.lastSince == "" {
+		t.Error("Expected commits to be listed with since")
+	}
+
+	// A merged branch brings an old commit that since would miss: refetch
+	fake.pushedAt = time.Now().Add(2 * time.Hour)
+	fake.history = append([]fakeCommit{
+		{sha: "m1", author: "octocat", committed: day(4)},
+		{sha: "old", author: "octocat", committed: day(1)},
+	}, fake.history...)
+	fake.compare = map[string]interface{}{"status": "ahead", "ahead_by": 2}
+	if got := commitSHAs(t, newIncrementalTestClient(t, server, stateDir, false)); got != "c1,c2,c3,m1,old" {
+		t.Errorf("Expected full history after merge, got: %s", got)
+	}
+	if fake.lastSince != "" {
+		t.Error("Expected a full refetch without since")
+	}
+}
+
+func TestGitHubClientFullRefreshAfterRewrite(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, 11, d, 12, 0, 0, 0, time.UTC) }
+	stateDir := t.TempDir()
+	fake := &fakeGitHub{
+		pushedAt: day(2),
+		history:  []fakeCommit{{sha: "c1", author: "octocat", committed: day(1)}},
+	}
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+	commitSHAs(t, newIncrementalTestClient(t, server, stateDir, false))
+
+	// Force-push replaced c1 with r1
+	fake.pushedAt = time.Now().Add(time.Hour)
+	fake.history = []fakeCommit{{sha: "r1", author: "octocat", committed: day(2)}}
+	fake.compare = map[string]interface{}{"status": "diverged", "ahead_by": 1, "behind_by": 1}
+	if got := commitSHAs(t, newIncrementalTestClient(t, server, stateDir, false)); got != "r1" {
+		t.Errorf("Expected rewritten history r1, got: %s", got)
+	}
+
+	// --full-refresh ignores the stored state even when nothing changed
+	fake.listRequests = 0
+	commitSHAs(t, newIncrementalTestClient(t, server, stateDir, true))
+	if fake.listRequests != 1 {
+		t.Errorf("Expected a full refetch, got: %d commit requests", fake.listRequests)
+	}
+}
+
+func TestGitHubClientAffiliatedRepositories(t *testing.T) {
+	writeJSON := func(w http.ResponseWriter, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}
+	commit := func(sha, login, email string) map[string]interface{} {
+		return map[string]interface{}{
+			"sha":    sha,
+			"author": map[string]string{"login": login},
+			"commit": map[string]interface{}{
+				"author":    map[string]string{"email": email, "date": "2023-11-14T10:00:00Z"},
+				"committer": map[string]string{"date": "2023-11-14T10:00:00Z"},
+			},
+		}
+	}
+
+	var mu sync.Mutex
+	var authorFilters []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/user", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"login": "octocat"})
+	})
+	mux.HandleFunc("/api/v3/user/repos", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("affiliation"); got != "owner,organization_member" {
+			t.Errorf("Expected affiliation filter, got: %q", got)
+		}
+		writeJSON(w, []map[string]interface{}{
+			{"name": "app", "full_name": "octocat/app", "owner": map[string]string{"login": "octocat"}},
+			{"name": "platform", "full_name": "acme/platform", "owner": map[string]string{"login": "acme"}},
+		})
+	})
+	mux.HandleFunc("/api/v3/repos/octocat/app/languages", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]int{"Go": 100})
+	})
+	mux.HandleFunc("/api/v3/repos/acme/platform/languages", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]int{"Go": 50, "Java": 300})
+	})
+	mux.HandleFunc("/api/v3/repos/octocat/app/commits", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]interface{}{commit("a1", "octocat", "octocat@example.com")})
+	})
+	mux.HandleFunc("/api/v3/repos/acme/platform/commits", func(w http.ResponseWriter, r *http.Request) {
+		author := r.URL.Query().Get("author")
+		mu.Lock()
+		authorFilters = append(authorFilters, author)
+		mu.Unlock()
+
+		switch author {
+		case "octocat":
+			writeJSON(w, []map[string]interface{}{commit("p1", "octocat", "octocat@example.com")})
+		case "me@work.example.com":
+			writeJSON(w, []map[string]interface{}{
+				commit("p2", "", "me@work.example.com"),
+				commit("p1", "octocat", "me@work.example.com"),
+			})
+		default:
+			t.Errorf("Expected commits of other repositories to be filtered by author, got: %q", author)
+			writeJSON(w, []map[string]interface{}{})
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewGitHubEnterpriseClient(server.URL, "token", false,
+		WithAffiliation([]string{"owner", "organization_member"}),
+		WithAuthorEmails([]string{"me@work.example.com"}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	repos, err := client.GetRepositories(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("GetRepositories failed: %v", err)
+	}
+	languages := sumLanguages(repos)
+	if languages["Go"] != 150 || languages["Java"] != 300 {
+		t.Errorf("Expected languages of both repositories, got: %v", languages)
+	}
+
+	if got := commitSHAs(t, client); got != "a1,p1,p2" {
+		t.Errorf("Expected own and organization commits without duplicates, got: %s", got)
+	}
+	sort.Strings(authorFilters)
+	if strings.Join(authorFilters, ",") != "me@work.example.com,octocat" {
+		t.Errorf("Expected one filtered listing per identity, got: %v", authorFilters)
+	}
+}
+
+func TestGitHubClientSkipsInaccessibleRepositories(t *testing.T) {
+	writeJSON := func(w http.ResponseWriter, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}
+	commits := []map[string]interface{}{{
+		"sha":    "a1",
+		"author": map[string]string{"login": "octocat"},
+		"commit": map[string]interface{}{
+			"author":    map[string]string{"email": "octocat@example.com", "date": "2023-11-14T10:00:00Z"},
+			"committer": map[string]string{"date": "2023-11-14T10:00:00Z"},
+		},
+	}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/users/octocat/repos", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]interface{}{
+			{"name": "app", "full_name": "octocat/app", "owner": map[string]string{"login": "octocat"}},
+			{"name": "sso", "full_name": "octocat/sso", "owner": map[string]string{"login": "octocat"}},
+			{"name": "dmca", "full_name": "octocat/dmca", "owner": map[string]string{"login": "octocat"}},
+		})
+	})
+	mux.HandleFunc("/api/v3/repos/octocat/app/languages", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]int{"Go": 100})
+	})
+	mux.HandleFunc("/api/v3/repos/octocat/app/commits", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, commits)
+	})
+	mux.HandleFunc("/api/v3/repos/octocat/sso/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		writeJSON(w, map[string]string{"message": "Resource protected by organization SAML enforcement."})
+	})
+	mux.HandleFunc("/api/v3/repos/octocat/dmca/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnavailableForLegalReasons)
+		writeJSON(w, map[string]string{"message": "Repository access blocked"})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewGitHubEnterpriseClient(server.URL, "token", false)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	repos, err := client.GetRepositories(ctx, "octocat")
+	if err != nil {
+		t.Fatalf("GetRepositories failed: %v", err)
+	}
+	if len(repos) != 1 || repos[0].Name != "app" {
+		t.Errorf("Expected only the readable repository, got: %+v", repos)
+	}
+
+	if got := commitSHAs(t, client); got != "a1" {
+		t.Errorf("Expected the commits of the readable repository, got: %s", got)
+	}
+
+	orgCommits, err := client.GetOrganizationCommits(ctx, []domain.Repository{
+		{Owner: "octocat", Name: "app"},
+		{Owner: "octocat", Name: "sso"},
+	}, []string{"octocat"})
+	if err != nil {
+		t.Fatalf("GetOrganizationCommits failed: %v", err)
+	}
+	if len(orgCommits) != 1 {
+		t.Errorf("Expected the commit of the readable repository, got: %+v", orgCommits)
+	}
+
+	// Rate limits and server errors still stop the run
+	for _, resp := range []*http.Response{
+		{StatusCode: http.StatusForbidden, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{"message": "You have exceeded a secondary rate limit."}`))},
+		{StatusCode: http.StatusBadGateway, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{}`))},
+	} {
+		if skipRepository("octocat/app", github.CheckResponse(resp)) {
+			t.Errorf("Expected a %d to stop the run", resp.StatusCode)
+		}
+	}
+}
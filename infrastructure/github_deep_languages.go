@@ -0,0 +1,87 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"path"
+
+	"github.com/google/go-github/v38/github"
+)
+
+// repositoryLanguages returns the byte count per language of a repository:
+// GitHub's own totals, or in deep mode totals recomputed from the file tree
+func (g *GitHubClient) repositoryLanguages(ctx context.Context, repo *github.Repository) (map[string]int, error) {
+	owner, name := repo.GetOwner().GetLogin(), repo.GetName()
+	if g.deepLanguages {
+		return g.treeLanguages(ctx, repo)
+	}
+
+	languages, _, err := g.client.Repositories.ListLanguages(ctx, owner, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch languages for %s: %w", name, err)
+	}
+	return languages, nil
+}
+
+// treeLanguages walks the default branch and sums the size of every file per
+// language, honouring the linguist overrides of all .gitattributes files.
+// Trees too large for a single recursive listing fall back to GitHub's totals.
+func (g *GitHubClient) treeLanguages(ctx context.Context, repo *github.Repository) (map[string]int, error) {
+	owner, name := repo.GetOwner().GetLogin(), repo.GetName()
+	ref := repo.GetDefaultBranch()
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	tree, resp, err := g.client.Git.GetTree(ctx, owner, name, ref, true)
+	if resp != nil && resp.StatusCode == http.StatusConflict {
+		// GitHub answers 409 for empty repositories
+		return map[string]int{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch file tree for %s: %w", name, err)
+	}
+
+	if tree.GetTruncated() {
+		log.Printf("  ↻ %s: file tree too large, using GitHub's language totals\n", name)
+		languages, _, err := g.client.Repositories.ListLanguages(ctx, owner, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch languages for %s: %w", name, err)
+		}
+		return languages, nil
+	}
+
+	// Read every .gitattributes file through the contents API
+	attributes := newGitAttributes()
+	for _, entry := range tree.Entries {
+		if entry.GetType() != "blob" || path.Base(entry.GetPath()) != ".gitattributes" {
+			continue
+		}
+
+		file, _, _, err := g.client.Repositories.GetContents(ctx, owner, name, entry.GetPath(),
+			&github.RepositoryContentGetOptions{Ref: ref})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s of %s: %w", entry.GetPath(), name, err)
+		}
+		content, err := file.GetContent()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s of %s: %w", entry.GetPath(), name, err)
+		}
+		attributes.addFile(entry.GetPath(), content)
+	}
+
+	classifier := newLinguistClassifier(attributes)
+	languages := make(map[string]int)
+	for _, entry := range tree.Entries {
+		if entry.GetType() != "blob" {
+			continue
+		}
+		if lang := classifier.language(entry.GetPath()); lang != "" {
+			languages[lang] += entry.GetSize()
+		}
+	}
+
+	return languages, nil
+}
@@ -0,0 +1,88 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitHubClientDeepLanguages(t *testing.T) {
+	writeJSON := func(w http.ResponseWriter, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}
+	blob := func(path string, size int) map[string]interface{} {
+		return map[string]interface{}{"path": path, "type": "blob", "size": size}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/users/octocat/repos", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]interface{}{{
+			"name":           "app",
+			"full_name":      "octocat/app",
+			"owner":          map[string]string{"login": "octocat"},
+			"default_branch": "main",
+		}})
+	})
+	mux.HandleFunc("/api/v3/repos/octocat/app/git/trees/main", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("recursive") != "1" {
+			t.Error("Expected a recursive tree listing")
+		}
+		writeJSON(w, map[string]interface{}{
+			"sha": "abc",
+			"tree": []map[string]interface{}{
+				blob(".gitattributes", 60),
+				blob("main.go", 1000),
+				blob("api/user.pb.go", 5000),
+				{"path": "third_party", "type": "tree"},
+				blob("third_party/lib.c", 9000),
+				blob("web/bundle.js", 7000),
+				blob("web/app.ts", 300),
+			},
+		})
+	})
+	mux.HandleFunc("/api/v3/repos/octocat/app/contents/.gitattributes", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ref") != "main" {
+			t.Errorf("Expected .gitattributes of the default branch, got ref %q", r.URL.Query().Get("ref"))
+		}
+		content := "web/bundle.js linguist-generated\nthird_party/** -linguist-vendored\n"
+		writeJSON(w, map[string]string{
+			"type":     "file",
+			"encoding": "base64",
+			"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+		})
+	})
+	mux.HandleFunc("/api/v3/repos/octocat/app/languages", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected languages to be computed from the tree")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewGitHubEnterpriseClient(server.URL, "token", false, WithDeepLanguages())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	repos, err := client.GetRepositories(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("GetRepositories failed: %v", err)
+	}
+	languages := sumLanguages(repos)
+
+	// The generated protobuf code and bundle are skipped; third_party was
+	// explicitly un-vendored
+	want := map[string]int{"Go": 1000, "C": 9000, "TypeScript": 300}
+	if len(languages) != len(want) {
+		t.Fatalf("Expected %v, got: %v", want, languages)
+	}
+	for lang, bytes := range want {
+		if languages[lang] != bytes {
+			t.Errorf("Expected %s=%d, got: %d", lang, bytes, languages[lang])
+		}
+	}
+}
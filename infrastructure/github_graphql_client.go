@@ -0,0 +1,376 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"GitInsights/domain"
+)
+
+const (
+	githubGraphQLEndpoint = "https://api.github.com/graphql"
+
+	viewerQuery = `query {
+  viewer { login createdAt }
+}`
+
+	userQuery = `query($login: String!) {
+  user(login: $login) { login createdAt }
+}`
+
+	repositoryLanguagesQuery = `query($login: String!, $cursor: String) {
+  user(login: $login) {
+    repositories(first: 100, after: $cursor, ownerAffiliations: OWNER) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        name
+        owner { login }
+        isFork
+        pushedAt
+        languages(first: 100) { edges { size node { name } } }
+      }
+    }
+  }
+}`
+
+	commitContributionsQuery = `query($login: String!, $from: DateTime!, $to: DateTime!, $cursor: String) {
+  user(login: $login) {
+    contributionsCollection(from: $from, to: $to) {
+      totalCommitContributions
+      commitContributionsByRepository(maxRepositories: 100) {
+        repository { nameWithOwner }
+        contributions(first: 100, after: $cursor) {
+          pageInfo { hasNextPage endCursor }
+          nodes { occurredAt commitCount }
+        }
+      }
+    }
+  }
+}`
+)
+
+// GitHubGraphQLClient implements domain.GitHubRepository with the GitHub
+// GraphQL API. Commit activity comes from the commit contributions of each
+// year, which need about one request per year instead of one per page of
+// commits per repository and also cover repositories the user doesn't own.
+// Contributions only have daily counts per repository, so the commits they
+// produce are marked DateOnly.
+type GitHubGraphQLClient struct {
+	endpoint     string
+	httpClient   *http.Client
+	includeForks bool
+}
+
+// NewGitHubGraphQLClient creates a GraphQL client for github.com, or for
+// GitHub Enterprise Server when baseURL is set. It takes the REST client's
+// options, of which the request scheduling and caching ones apply.
+func NewGitHubGraphQLClient(baseURL, token string, includeForks bool, opts ...GitHubClientOption) *GitHubGraphQLClient {
+	endpoint := githubGraphQLEndpoint
+	if baseURL != "" {
+		endpoint = strings.TrimRight(baseURL, "/") + "/api/graphql"
+	}
+
+	return &GitHubGraphQLClient{
+		endpoint:     endpoint,
+		httpClient:   newGitHubClient(includeForks, opts).httpClient(hostOf(endpoint), token),
+		includeForks: includeForks,
+	}
+}
+
+// query executes a GraphQL query and decodes its data into v
+func (g *GitHubGraphQLClient) query(ctx context.Context, query string, variables map[string]interface{}, v interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("graphql request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("graphql request failed: unexpected status %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode graphql response: %w", err)
+	}
+
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("graphql error: %s", result.Errors[0].Message)
+	}
+
+	if err := json.Unmarshal(result.Data, v); err != nil {
+		return fmt.Errorf("failed to decode graphql data: %w", err)
+	}
+	return nil
+}
+
+// user retrieves the given user, or the authenticated user when login is empty
+func (g *GitHubGraphQLClient) user(ctx context.Context, login string) (*domain.UserProfile, error) {
+	type account struct {
+		Login     string    `json:"login"`
+		CreatedAt time.Time `json:"createdAt"`
+	}
+	var data struct {
+		Viewer *account `json:"viewer"`
+		User   *account `json:"user"`
+	}
+
+	query, variables := viewerQuery, map[string]interface{}(nil)
+	if login != "" {
+		query, variables = userQuery, map[string]interface{}{"login": login}
+	}
+	if err := g.query(ctx, query, variables, &data); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	result := data.Viewer
+	if login != "" {
+		result = data.User
+	}
+	if result == nil {
+		return nil, fmt.Errorf("user %s not found", login)
+	}
+	if result.Login == "" {
+		return nil, fmt.Errorf("user login is empty")
+	}
+
+	return &domain.UserProfile{
+		Username:  result.Login,
+		CreatedAt: result.CreatedAt,
+	}, nil
+}
+
+// GetUsername retrieves the authenticated user's username
+func (g *GitHubGraphQLClient) GetUsername(ctx context.Context) (string, error) {
+	profile, err := g.user(ctx, "")
+	if err != nil {
+		return "", err
+	}
+	return profile.Username, nil
+}
+
+// GetUserProfile retrieves the profile of the given user, or of the
+// authenticated user when username is empty
+func (g *GitHubGraphQLClient) GetUserProfile(ctx context.Context, username string) (*domain.UserProfile, error) {
+	return g.user(ctx, username)
+}
+
+// GetRepositories lists the user's repositories with their language byte sizes
+func (g *GitHubGraphQLClient) GetRepositories(ctx context.Context, username string) ([]domain.Repository, error) {
+	var data struct {
+		User struct {
+			Repositories struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []struct {
+					Name  string `json:"name"`
+					Owner struct {
+						Login string `json:"login"`
+					} `json:"owner"`
+					IsFork    bool      `json:"isFork"`
+					PushedAt  time.Time `json:"pushedAt"`
+					Languages struct {
+						Edges []struct {
+							Size int `json:"size"`
+							Node struct {
+								Name string `json:"name"`
+							} `json:"node"`
+						} `json:"edges"`
+					} `json:"languages"`
+				} `json:"nodes"`
+			} `json:"repositories"`
+		} `json:"user"`
+	}
+
+	var repositories []domain.Repository
+	variables := map[string]interface{}{"login": username, "cursor": nil}
+
+	for {
+		if err := g.query(ctx, repositoryLanguagesQuery, variables, &data); err != nil {
+			return nil, fmt.Errorf("failed to list repositories: %w", err)
+		}
+
+		for _, repo := range data.User.Repositories.Nodes {
+			if repo.IsFork && !g.includeForks {
+				continue
+			}
+			languages := make(map[string]int, len(repo.Languages.Edges))
+			for _, edge := range repo.Languages.Edges {
+				languages[edge.Node.Name] += edge.Size
+			}
+			repositories = append(repositories, domain.Repository{
+				Owner:     repo.Owner.Login,
+				Name:      repo.Name,
+				Languages: languages,
+				PushedAt:  repo.PushedAt,
+			})
+		}
+
+		if !data.User.Repositories.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = data.User.Repositories.PageInfo.EndCursor
+	}
+
+	log.Printf("Analyzed languages across %d repositories via GraphQL\n", len(repositories))
+	return repositories, nil
+}
+
+// commitContributions is one year's commit contributions, grouped by
+// repository, with one node per day
+type commitContributions struct {
+	User *struct {
+		ContributionsCollection struct {
+			TotalCommitContributions        int `json:"totalCommitContributions"`
+			CommitContributionsByRepository []struct {
+				Repository struct {
+					NameWithOwner string `json:"nameWithOwner"`
+				} `json:"repository"`
+				Contributions struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						OccurredAt  time.Time `json:"occurredAt"`
+						CommitCount int       `json:"commitCount"`
+					} `json:"nodes"`
+				} `json:"contributions"`
+			} `json:"commitContributionsByRepository"`
+		} `json:"contributionsCollection"`
+	} `json:"user"`
+}
+
+// GetAllCommits turns the commit contributions into one date-only commit per
+// commit, querying one year at a time from account creation until now.
+// Issues, pull requests and reviews are not counted.
+func (g *GitHubGraphQLClient) GetAllCommits(ctx context.Context, username string) ([]domain.Commit, error) {
+	profile, err := g.user(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	var allCommits []domain.Commit
+	now := time.Now().UTC()
+	for from := profile.CreatedAt.UTC(); from.Before(now); from = from.AddDate(1, 0, 0) {
+		// contributionsCollection spans at most one year
+		to := from.AddDate(1, 0, 0).Add(-time.Second)
+		if to.After(now) {
+			to = now
+		}
+
+		commits, uncounted, err := g.yearCommits(ctx, username, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commit contributions for %d: %w", from.Year(), err)
+		}
+		if uncounted > 0 {
+			log.Printf("⚠️  %s to %s: %d of %d commits are in repositories beyond the first 100 and not counted; use --github-api=rest to count them\n",
+				from.Format("2006-01-02"), to.Format("2006-01-02"), uncounted, len(commits)+uncounted)
+		}
+		allCommits = append(allCommits, commits...)
+	}
+
+	log.Printf("Total commits analyzed: %d\n", len(allCommits))
+	return allCommits, nil
+}
+
+// yearCommits returns the commits between from and to, and how many of the
+// year's total are missing from them because GitHub only breaks down the
+// first 100 repositories. Repositories with more than one page of
+// contributions are paged through one at a time.
+func (g *GitHubGraphQLClient) yearCommits(ctx context.Context, username string, from, to time.Time) ([]domain.Commit, int, error) {
+	variables := map[string]interface{}{
+		"login":  username,
+		"from":   from.Format(time.RFC3339),
+		"to":     to.Format(time.RFC3339),
+		"cursor": nil,
+	}
+
+	// fetch queries a page of every repository's contributions
+	fetch := func() (*commitContributions, error) {
+		var data commitContributions
+		if err := g.query(ctx, commitContributionsQuery, variables, &data); err != nil {
+			return nil, err
+		}
+		if data.User == nil {
+			return nil, fmt.Errorf("user %s not found", username)
+		}
+		return &data, nil
+	}
+
+	data, err := fetch()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var commits []domain.Commit
+	for _, byRepo := range data.User.ContributionsCollection.CommitContributionsByRepository {
+		name := byRepo.Repository.NameWithOwner
+		contributions := byRepo.Contributions
+		for {
+			for _, node := range contributions.Nodes {
+				year, month, day := node.OccurredAt.Date()
+				date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+				for i := 0; i < node.CommitCount; i++ {
+					commits = append(commits, domain.Commit{
+						Date:        date,
+						DateOnly:    true,
+						Repository:  name,
+						Attribution: domain.AttributedToLogin,
+					})
+				}
+			}
+			if !contributions.PageInfo.HasNextPage {
+				break
+			}
+
+			// The cursor pages every repository; only this one is read
+			variables["cursor"] = contributions.PageInfo.EndCursor
+			next, err := fetch()
+			if err != nil {
+				return nil, 0, err
+			}
+			found := false
+			for _, other := range next.User.ContributionsCollection.CommitContributionsByRepository {
+				if other.Repository.NameWithOwner == name {
+					contributions, found = other.Contributions, true
+					break
+				}
+			}
+			if !found {
+				return nil, 0, fmt.Errorf("repository %s missing from the next page of contributions", name)
+			}
+		}
+	}
+	uncounted := max(data.User.ContributionsCollection.TotalCommitContributions-len(commits), 0)
+	return commits, uncounted, nil
+}
@@ -0,0 +1,209 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newGraphQLTestServer answers the viewer, repository and commit
+// contribution queries of GitHubGraphQLClient
+func newGraphQLTestServer(t *testing.T, createdAt time.Time, contributionRequests *int) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+
+		var data string
+		switch {
+		case strings.Contains(req.Query, "viewer"):
+			data = `{"viewer": {"login": "octocat", "createdAt": "` + createdAt.Format(time.RFC3339) + `"}}`
+		case strings.Contains(req.Query, "createdAt"):
+			data = `{"user": {"login": "` + req.Variables["login"].(string) + `", "createdAt": "` + createdAt.Format(time.RFC3339) + `"}}`
+		case strings.Contains(req.Query, "repositories") && req.Variables["cursor"] == nil:
+			data = `{"user": {"repositories": {
+				"pageInfo": {"hasNextPage": true, "endCursor": "page2"},
+				"nodes": [
+					{"nameWithOwner": "octocat/app", "isFork": false, "languages": {"edges": [{"size": 900, "node": {"name": "Go"}}]}},
+					{"nameWithOwner": "octocat/fork", "isFork": true, "languages": {"edges": [{"size": 5000, "node": {"name": "C"}}]}}
+				]}}}`
+		case strings.Contains(req.Query, "repositories"):
+			data = `{"user": {"repositories": {
+				"pageInfo": {"hasNextPage": false, "endCursor": ""},
+				"nodes": [
+					{"nameWithOwner": "octocat/site", "isFork": false, "languages": {"edges": [{"size": 100, "node": {"name": "Go"}}, {"size": 50, "node": {"name": "CSS"}}]}}
+				]}}}`
+		case strings.Contains(req.Query, "contributionsCollection") && req.Variables["cursor"] == nil:
+			*contributionRequests++
+			data = `{"user": {"contributionsCollection": {"totalCommitContributions": 4, "commitContributionsByRepository": [
+				{"repository": {"nameWithOwner": "octocat/app"}, "contributions": {
+					"pageInfo": {"hasNextPage": true, "endCursor": "page2"},
+					"nodes": [{"occurredAt": "2023-11-13T08:00:00Z", "commitCount": 2}]}},
+				{"repository": {"nameWithOwner": "octocat/site"}, "contributions": {
+					"pageInfo": {"hasNextPage": false, "endCursor": ""},
+					"nodes": [{"occurredAt": "2023-11-14T08:00:00Z", "commitCount": 1}]}}
+			]}}}`
+		case strings.Contains(req.Query, "contributionsCollection"):
+			*contributionRequests++
+			data = `{"user": {"contributionsCollection": {"totalCommitContributions": 4, "commitContributionsByRepository": [
+				{"repository": {"nameWithOwner": "octocat/app"}, "contributions": {
+					"pageInfo": {"hasNextPage": false, "endCursor": ""},
+					"nodes": [{"occurredAt": "2023-11-15T08:00:00Z", "commitCount": 1}]}},
+				{"repository": {"nameWithOwner": "octocat/site"}, "contributions": {
+					"pageInfo": {"hasNextPage": false, "endCursor": ""},
+					"nodes": []}}
+			]}}}`
+		default:
+			t.Errorf("Unexpected query: %s", req.Query)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": ` + data + `}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGitHubGraphQLClientLanguageStats(t *testing.T) {
+	calendarRequests := 0
+	server := newGraphQLTestServer(t, time.Now().AddDate(-1, 0, 0), &calendarRequests)
+
+	client := NewGitHubGraphQLClient("", "token", false)
+	client.endpoint = server.URL
+
+	repos, err := client.GetRepositories(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("GetRepositories failed: %v", err)
+	}
+	languages := sumLanguages(repos)
+
+	if languages["Go"] != 1000 || languages["CSS"] != 50 {
+		t.Errorf("Expected Go=1000 and CSS=50 across both pages, got: %v", languages)
+	}
+	if _, ok := languages["C"]; ok {
+		t.Error("Expected the fork's languages to be excluded")
+	}
+}
+
+func TestGitHubGraphQLClientCommitContributions(t *testing.T) {
+	contributionRequests := 0
+	// An account created two and a half years ago needs three one-year
+	// windows, each with a second page for octocat/app
+	server := newGraphQLTestServer(t, time.Now().AddDate(-2, -6, 0), &contributionRequests)
+
+	client := NewGitHubGraphQLClient("", "token", false)
+	client.endpoint = server.URL
+
+	commits, err := client.GetAllCommits(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("GetAllCommits failed: %v", err)
+	}
+
+	if contributionRequests != 6 {
+		t.Errorf("Expected 2 contribution queries per yearly window, got: %d", contributionRequests)
+	}
+	if len(commits) != 12 {
+		t.Fatalf("Expected 4 commits per yearly window, got: %d", len(commits))
+	}
+
+	perDay := make(map[string]int)
+	for _, commit := range commits {
+		if !commit.DateOnly {
+			t.Errorf("Expected date-only commits, got: %+v", commit)
+		}
+		perDay[commit.Repository+" "+commit.Date.Format("2006-01-02")]++
+	}
+	expected := map[string]int{
+		"octocat/app 2023-11-13":  6,
+		"octocat/site 2023-11-14": 3,
+		"octocat/app 2023-11-15":  3,
+	}
+	for day, count := range expected {
+		if perDay[day] != count {
+			t.Errorf("Expected %d commits for %s, got: %v", count, day, perDay)
+		}
+	}
+}
+
+func TestGitHubGraphQLClientUncountedRepositories(t *testing.T) {
+	// The year's total includes repositories past the first 100
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"user": {"contributionsCollection": {"totalCommitContributions": 10, "commitContributionsByRepository": [
+			{"repository": {"nameWithOwner": "octocat/app"}, "contributions": {
+				"pageInfo": {"hasNextPage": false, "endCursor": ""},
+				"nodes": [{"occurredAt": "2023-11-13T08:00:00Z", "commitCount": 3}]}}
+		]}}}}`))
+	}))
+	defer server.Close()
+
+	client := NewGitHubGraphQLClient("", "token", false)
+	client.endpoint = server.URL
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	commits, uncounted, err := client.yearCommits(context.Background(), "octocat", from, from.AddDate(1, 0, 0).Add(-time.Second))
+	if err != nil {
+		t.Fatalf("yearCommits failed: %v", err)
+	}
+	if len(commits) != 3 || uncounted != 7 {
+		t.Errorf("Expected 3 commits and 7 uncounted, got: %d and %d", len(commits), uncounted)
+	}
+}
+
+func TestGitHubGraphQLClientMissingUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query string `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if strings.Contains(req.Query, "contributionsCollection") {
+			w.Write([]byte(`{"data": {"user": null}}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"user": {"login": "octocat", "createdAt": "` + time.Now().AddDate(-1, -6, 0).Format(time.RFC3339) + `"}}}`))
+	}))
+	defer server.Close()
+
+	client := NewGitHubGraphQLClient("", "token", false)
+	client.endpoint = server.URL
+
+	if _, err := client.GetAllCommits(context.Background(), "octocat"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected a missing user to fail, got: %v", err)
+	}
+}
+
+func TestGitHubGraphQLClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": null, "errors": [{"message": "Bad credentials"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewGitHubGraphQLClient("", "token", false)
+	client.endpoint = server.URL
+
+	if _, err := client.GetUsername(context.Background()); err == nil || !strings.Contains(err.Error(), "Bad credentials") {
+		t.Errorf("Expected GraphQL error to be reported, got: %v", err)
+	}
+}
+
+func TestGitHubGraphQLClientOptions(t *testing.T) {
+	client := NewGitHubGraphQLClient("", "token", false, WithMaxConcurrentRequests(3))
+	scheduler, ok := client.httpClient.Transport.(*RequestScheduler)
+	if !ok || cap(scheduler.slots) != 3 {
+		t.Errorf("Expected a scheduler with 3 slots, got: %#v", client.httpClient.Transport)
+	}
+
+	client = NewGitHubGraphQLClient("", "token", false, WithCache(t.TempDir(), time.Hour))
+	if _, ok := client.httpClient.Transport.(*HTTPCache); !ok {
+		t.Errorf("Expected the response cache in front of the scheduler, got: %#v", client.httpClient.Transport)
+	}
+}
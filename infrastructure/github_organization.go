@@ -0,0 +1,167 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"GitInsights/domain"
+
+	"github.com/google/go-github/v38/github"
+)
+
+// memberMatcher keeps the commits authored by any of a set of logins
+type memberMatcher map[string]bool
+
+// newMemberMatcher creates a matcher for the given logins, ignoring case
+func newMemberMatcher(members []string) memberMatcher {
+	matcher := make(memberMatcher, len(members))
+	for _, member := range members {
+		matcher[strings.ToLower(member)] = true
+	}
+	return matcher
+}
+
+// Match reports whether the commit author is one of the members
+func (m memberMatcher) Match(authorLogin, _, _ string) (domain.CommitAttribution, bool) {
+	if !m[strings.ToLower(authorLogin)] {
+		return "", false
+	}
+	return domain.AttributedToLogin, true
+}
+
+// GetOrganizationProfile retrieves an organization's name and creation date
+func (g *GitHubClient) GetOrganizationProfile(ctx context.Context, org string) (*domain.UserProfile, error) {
+	organization, _, err := g.client.Organizations.Get(ctx, org)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization %s: %w", org, err)
+	}
+
+	if organization.CreatedAt == nil {
+		return nil, fmt.Errorf("organization created_at is nil")
+	}
+
+	return &domain.UserProfile{
+		Username:  organization.GetLogin(),
+		CreatedAt: *organization.CreatedAt,
+	}, nil
+}
+
+// GetOrganizationMembers lists the logins of an organization's members, or of
+// a team's members when team is set
+func (g *GitHubClient) GetOrganizationMembers(ctx context.Context, org, team string) ([]string, error) {
+	var members []string
+	listOpts := github.ListOptions{PerPage: 100}
+
+	for {
+		var users []*github.User
+		var resp *github.Response
+		var err error
+		if team != "" {
+			users, resp, err = g.client.Teams.ListTeamMembersBySlug(ctx, org, team,
+				&github.TeamListTeamMembersOptions{ListOptions: listOpts})
+		} else {
+			users, resp, err = g.client.Organizations.ListMembers(ctx, org,
+				&github.ListMembersOptions{ListOptions: listOpts})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list members: %w", err)
+		}
+
+		for _, user := range users {
+			members = append(members, user.GetLogin())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		listOpts.Page = resp.NextPage
+	}
+
+	return members, nil
+}
+
+// GetOrganizationRepositories lists an organization's repositories, or the
+// repositories a team has access to, with their languages
+func (g *GitHubClient) GetOrganizationRepositories(ctx context.Context, org, team string) ([]domain.Repository, error) {
+	var allRepos []*github.Repository
+	listOpts := github.ListOptions{PerPage: 100}
+
+	for {
+		var repos []*github.Repository
+		var resp *github.Response
+		var err error
+		if team != "" {
+			repos, resp, err = g.client.Teams.ListTeamReposBySlug(ctx, org, team, &listOpts)
+		} else {
+			repos, resp, err = g.client.Repositories.ListByOrg(ctx, org,
+				&github.RepositoryListByOrgOptions{ListOptions: listOpts})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories: %w", err)
+		}
+		allRepos = append(allRepos, repos...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		listOpts.Page = resp.NextPage
+	}
+	allRepos = g.filterRepositories(allRepos)
+
+	log.Printf("Analyzing languages across %d repositories of %s%s...\n", len(allRepos), org, g.forksSuffix())
+
+	return g.withLanguages(ctx, allRepos)
+}
+
+// GetOrganizationCommits retrieves the members' commits on the default branch
+// of every repository, matched by the login GitHub linked each commit to
+func (g *GitHubClient) GetOrganizationCommits(ctx context.Context, repositories []domain.Repository, members []string) ([]domain.Commit, error) {
+	matcher := newMemberMatcher(members)
+	commitsByRepo := make(map[string][]domain.Commit)
+	var mu sync.Mutex
+
+	log.Printf("Fetching commits of %d members from %d repositories...\n", len(members), len(repositories))
+
+	err := runConcurrently(len(repositories), func(i int) error {
+		repo := repositories[i]
+		state, _, err := g.listCommits(ctx, repo.Owner, repo.Name, time.Time{}, "", matcher)
+		if err != nil {
+			if skipRepository(repo.FullName(), err) {
+				return nil
+			}
+			return err
+		}
+
+		if len(state.Commits) > 0 {
+			log.Printf("  ✓ %s: %d commits\n", repo.Name, len(state.Commits))
+		}
+
+		mu.Lock()
+		commitsByRepo[repo.FullName()] = state.Commits
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Combine repositories in a stable order
+	names := make([]string, 0, len(commitsByRepo))
+	for name := range commitsByRepo {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var allCommits []domain.Commit
+	for _, name := range names {
+		allCommits = append(allCommits, commitsByRepo[name]...)
+	}
+
+	log.Printf("Total commits analyzed: %d\n", len(allCommits))
+	return allCommits, nil
+}
@@ -0,0 +1,129 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"GitInsights/domain"
+)
+
+// newOrganizationTestClient serves the organization "acme" with a team
+// "platform", a regular repository and a fork
+func newOrganizationTestClient(t *testing.T) *GitHubClient {
+	t.Helper()
+
+	writeJSON := func(w http.ResponseWriter, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}
+	user := func(login string) map[string]string { return map[string]string{"login": login} }
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/orgs/acme", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"login": "acme", "created_at": "2015-02-01T00:00:00Z"})
+	})
+	mux.HandleFunc("/api/v3/orgs/acme/members", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]string{user("alice"), user("bob")})
+	})
+	mux.HandleFunc("/api/v3/orgs/acme/teams/platform/members", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]string{user("alice")})
+	})
+	mux.HandleFunc("/api/v3/orgs/acme/repos", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]interface{}{
+			{"name": "api", "owner": user("acme"), "pushed_at": "2023-11-15T00:00:00Z"},
+			{"name": "fork", "owner": user("acme"), "fork": true},
+		})
+	})
+	mux.HandleFunc("/api/v3/repos/acme/api/languages", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]int{"Go": 900, "Shell": 100})
+	})
+	mux.HandleFunc("/api/v3/repos/acme/api/commits", func(w http.ResponseWriter, r *http.Request) {
+		commit := func(sha, login string) map[string]interface{} {
+			return map[string]interface{}{
+				"sha":    sha,
+				"author": user(login),
+				"commit": map[string]interface{}{
+					"author":    map[string]string{"email": login + "@example.com", "date": "2023-11-14T10:00:00Z"},
+					"committer": map[string]string{"date": "2023-11-14T10:00:00Z"},
+				},
+			}
+		}
+		writeJSON(w, []map[string]interface{}{commit("c3", "dependabot"), commit("c2", "Alice"), commit("c1", "bob")})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewGitHubEnterpriseClient(server.URL, "token", false)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestGitHubClientOrganizationMembers(t *testing.T) {
+	client := newOrganizationTestClient(t)
+	ctx := context.Background()
+
+	members, err := client.GetOrganizationMembers(ctx, "acme", "")
+	if err != nil {
+		t.Fatalf("GetOrganizationMembers failed: %v", err)
+	}
+	if strings.Join(members, ",") != "alice,bob" {
+		t.Errorf("Expected alice and bob, got: %v", members)
+	}
+
+	members, err = client.GetOrganizationMembers(ctx, "acme", "platform")
+	if err != nil {
+		t.Fatalf("GetOrganizationMembers failed: %v", err)
+	}
+	if strings.Join(members, ",") != "alice" {
+		t.Errorf("Expected only the team member alice, got: %v", members)
+	}
+}
+
+func TestGitHubClientOrganizationRepositoriesAndCommits(t *testing.T) {
+	client := newOrganizationTestClient(t)
+	ctx := context.Background()
+
+	profile, err := client.GetOrganizationProfile(ctx, "acme")
+	if err != nil {
+		t.Fatalf("GetOrganizationProfile failed: %v", err)
+	}
+	if profile.Username != "acme" || profile.CreatedAt.Year() != 2015 {
+		t.Errorf("Expected acme created in 2015, got: %+v", profile)
+	}
+
+	repos, err := client.GetOrganizationRepositories(ctx, "acme", "")
+	if err != nil {
+		t.Fatalf("GetOrganizationRepositories failed: %v", err)
+	}
+	if len(repos) != 1 || repos[0].FullName() != "acme/api" || repos[0].Languages["Go"] != 900 {
+		t.Fatalf("Expected acme/api with its languages and without the fork, got: %+v", repos)
+	}
+
+	commits, err := client.GetOrganizationCommits(ctx, repos, []string{"alice", "bob"})
+	if err != nil {
+		t.Fatalf("GetOrganizationCommits failed: %v", err)
+	}
+
+	// The bot isn't a member; logins match regardless of case
+	want := []domain.Commit{
+		{SHA: "c2", Author: "Alice", Repository: "acme/api"},
+		{SHA: "c1", Author: "bob", Repository: "acme/api"},
+	}
+	if len(commits) != len(want) {
+		t.Fatalf("Expected %d member commits, got: %+v", len(want), commits)
+	}
+	for i, commit := range commits {
+		if commit.SHA != want[i].SHA || commit.Author != want[i].Author || commit.Repository != want[i].Repository {
+			t.Errorf("Expected commit %+v, got: %+v", want[i], commit)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"GitInsights/domain"
+)
+
+// GitLabClient implements domain.GitHubRepository for GitLab.com and
+// self-hosted GitLab instances through the REST API v4
+type GitLabClient struct {
+	api          *restClient
+	includeForks bool
+	authorEmails []string
+}
+
+type gitlabUser struct {
+	ID          int       `json:"id"`
+	Username    string    `json:"username"`
+	Email       string    `json:"email"`
+	CommitEmail string    `json:"commit_email"`
+	PublicEmail string    `json:"public_email"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type gitlabProject struct {
+	ID                int             `json:"id"`
+	PathWithNamespace string          `json:"path_with_namespace"`
+	ForkedFromProject *struct{}       `json:"forked_from_project"`
+	Statistics        *gitlabProjStat `json:"statistics"`
+	LastActivityAt    time.Time       `json:"last_activity_at"`
+}
+
+type gitlabProjStat struct {
+	RepositorySize int64 `json:"repository_size"`
+}
+
+type gitlabCommit struct {
+	ID           string    `json:"id"`
+	AuthorEmail  string    `json:"author_email"`
+	AuthoredDate time.Time `json:"authored_date"`
+	Message      string    `json:"message"`
+}
+
+// NewGitLabClient creates a client for the GitLab instance at baseURL (e.g. https://gitlab.com)
+func NewGitLabClient(baseURL, token string, includeForks bool, authorEmails []string) *GitLabClient {
+	return &GitLabClient{
+		api: newRestClient(baseURL+"/api/v4", func(req *http.Request) {
+			req.Header.Set("PRIVATE-TOKEN", token)
+		}),
+		includeForks: includeForks,
+		authorEmails: authorEmails,
+	}
+}
+
+// currentUser retrieves the user the token belongs to
+func (g *GitLabClient) currentUser(ctx context.Context) (*gitlabUser, error) {
+	var user gitlabUser
+	if _, err := g.api.getJSON(ctx, "/user", nil, &user); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.Username == "" {
+		return nil, fmt.Errorf("user username is empty")
+	}
+
+	return &user, nil
+}
+
+// getUser retrieves the given user. The token owner is read from /user so
+// their private commit emails are known; anyone else is looked up by username
+// and only their public profile is available.
+func (g *GitLabClient) getUser(ctx context.Context, username string) (*gitlabUser, error) {
+	current, err := g.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if username == "" || strings.EqualFold(username, current.Username) {
+		return current, nil
+	}
+
+	var matches []gitlabUser
+	query := url.Values{"username": {username}}
+	if _, err := g.api.getJSON(ctx, "/users", query, &matches); err != nil {
+		return nil, fmt.Errorf("failed to look up user %s: %w", username, err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("user %s not found", username)
+	}
+
+	// The search result only has basic fields; the user endpoint adds
+	// created_at and the public email
+	var user gitlabUser
+	if _, err := g.api.getJSON(ctx, fmt.Sprintf("/users/%d", matches[0].ID), nil, &user); err != nil {
+		return nil, fmt.Errorf("failed to get user %s: %w", username, err)
+	}
+
+	return &user, nil
+}
+
+// GetUsername retrieves the authenticated user's username
+func (g *GitLabClient) GetUsername(ctx context.Context) (string, error) {
+	user, err := g.currentUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	return user.Username, nil
+}
+
+// GetUserProfile retrieves the profile of the given user, or of the
+// authenticated user when username is empty
+func (g *GitLabClient) GetUserProfile(ctx context.Context, username string) (*domain.UserProfile, error) {
+	user, err := g.getUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.CreatedAt.IsZero() {
+		return nil, fmt.Errorf("user created_at is empty")
+	}
+
+	return &domain.UserProfile{
+		Username:  user.Username,
+		CreatedAt: user.CreatedAt,
+	}, nil
+}
+
+// listProjects returns the user's projects, following X-Next-Page pagination
+func (g *GitLabClient) listProjects(ctx context.Context, username string) ([]gitlabProject, error) {
+	var allProjects []gitlabProject
+	query := url.Values{
+		"per_page":   {"100"},
+		"statistics": {"true"},
+	}
+
+	for page := "1"; page != ""; {
+		query.Set("page", page)
+
+		var projects []gitlabProject
+		resp, err := g.api.getJSON(ctx, "/users/"+url.PathEscape(username)+"/projects", query, &projects)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list projects: %w", err)
+		}
+		allProjects = append(allProjects, projects...)
+
+		page = resp.Header.Get("X-Next-Page")
+	}
+
+	if g.includeForks {
+		return allProjects, nil
+	}
+
+	var filtered []gitlabProject
+	for _, project := range allProjects {
+		if project.ForkedFromProject == nil {
+			filtered = append(filtered, project)
+		}
+	}
+	return filtered, nil
+}
+
+// GetRepositories lists the analyzed projects with their languages. GitLab
+// reports languages as percentages, so they are scaled by the repository size.
+// When the token can't see the size, the percentages themselves are used, so
+// such a project weighs 100 bytes. The last activity stands in for the last
+// push.
+func (g *GitLabClient) GetRepositories(ctx context.Context, username string) ([]domain.Repository, error) {
+	projects, err := g.listProjects(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Analyzing languages across %d GitLab projects...\n", len(projects))
+
+	var repositories []domain.Repository
+	unsized := 0
+	for _, project := range projects {
+		var percentages map[string]float64
+		path := "/projects/" + strconv.Itoa(project.ID) + "/languages"
+		if _, err := g.api.getJSON(ctx, path, nil, &percentages); err != nil {
+			return nil, fmt.Errorf("failed to get languages for %s: %w", project.PathWithNamespace, err)
+		}
+
+		// Without a size, each percentage point counts as a byte
+		scale := 1.0
+		if project.Statistics != nil && project.Statistics.RepositorySize > 0 {
+			scale = float64(project.Statistics.RepositorySize) / 100
+		} else {
+			unsized++
+		}
+
+		languages := make(map[string]int, len(percentages))
+		for lang, percentage := range percentages {
+			languages[lang] = int(math.Round(percentage * scale))
+		}
+
+		owner, name := "", project.PathWithNamespace
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			owner, name = name[:i], name[i+1:]
+		}
+		repositories = append(repositories, domain.Repository{
+			Owner:     owner,
+			Name:      name,
+			Languages: languages,
+			PushedAt:  project.LastActivityAt,
+		})
+	}
+
+	if unsized > 0 {
+		log.Printf("⚠️  %d GitLab projects don't show their size to this token; their languages are weighted by percentage only\n", unsized)
+	}
+	return repositories, nil
+}
+
+// GetAllCommits retrieves the user's commits across all projects
+func (g *GitLabClient) GetAllCommits(ctx context.Context, username string) ([]domain.Commit, error) {
+	projects, err := g.listProjects(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := g.getUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	emails := append([]string{user.Email, user.CommitEmail, user.PublicEmail}, g.authorEmails...)
+	matcher := NewAuthorMatcher("", emails)
+
+	log.Printf("Fetching commits from %d GitLab projects...\n", len(projects))
+
+	var allCommits []domain.Commit
+	for _, project := range projects {
+		query := url.Values{"per_page": {"100"}}
+		path := "/projects/" + strconv.Itoa(project.ID) + "/repository/commits"
+
+		repoCommitCount := 0
+		for page := "1"; page != ""; {
+			query.Set("page", page)
+
+			var commits []gitlabCommit
+			resp, err := g.api.getJSON(ctx, path, query, &commits)
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				// Projects without a repository have no commits
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to list commits for %s: %w", project.PathWithNamespace, err)
+			}
+
+			for _, commit := range commits {
+				attribution, ok := matcher.Match("", commit.AuthorEmail, commit.Message)
+				if !ok {
+					continue
+				}
+				allCommits = append(allCommits, domain.Commit{
+					SHA:         commit.ID,
+					Date:        commit.AuthoredDate,
+					Attribution: attribution,
+					Repository:  project.PathWithNamespace,
+				})
+				repoCommitCount++
+			}
+
+			page = resp.Header.Get("X-Next-Page")
+		}
+
+		if repoCommitCount > 0 {
+			log.Printf("  ✓ %s: %d commits\n", project.PathWithNamespace, repoCommitCount)
+		}
+	}
+
+	log.Printf("Total commits analyzed: %d\n", len(allCommits))
+	return allCommits, nil
+}
@@ -0,0 +1,166 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newGitLabTestServer serves a user with one regular project, one fork and
+// a paginated commit history
+func newGitLabTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	writeJSON := func(w http.ResponseWriter, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/user", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"id":           7,
+			"username":     "tanuki",
+			"commit_email": "tanuki@example.com",
+			"created_at":   "2019-03-01T10:00:00.000Z",
+		})
+	})
+	mux.HandleFunc("/api/v4/users", func(w http.ResponseWriter, r *http.Request) {
+		var matches []map[string]interface{}
+		if r.URL.Query().Get("username") == "kitsune" {
+			matches = append(matches, map[string]interface{}{"id": 8, "username": "kitsune"})
+		}
+		writeJSON(w, matches)
+	})
+	mux.HandleFunc("/api/v4/users/8", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"id":           8,
+			"username":     "kitsune",
+			"public_email": "kitsune@example.com",
+			"created_at":   "2016-05-20T08:00:00.000Z",
+		})
+	})
+	mux.HandleFunc("/api/v4/users/tanuki/projects", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]interface{}{
+			{"id": 1, "path_with_namespace": "tanuki/app", "statistics": map[string]int{"repository_size": 2000}},
+			{"id": 2, "path_with_namespace": "tanuki/fork", "forked_from_project": map[string]int{"id": 99}},
+			{"id": 3, "path_with_namespace": "tanuki/notes"},
+		})
+	})
+	mux.HandleFunc("/api/v4/projects/3/languages", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]float64{"Go": 40, "Python": 60})
+	})
+	mux.HandleFunc("/api/v4/projects/3/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]string{})
+	})
+	mux.HandleFunc("/api/v4/projects/1/languages", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]float64{"Go": 75, "Shell": 25})
+	})
+	mux.HandleFunc("/api/v4/projects/1/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("X-Next-Page", "2")
+			writeJSON(w, []map[string]string{
+				{"id": "a1", "author_email": "tanuki@example.com", "authored_date": "2023-11-13T09:00:00.000+07:00", "message": "Mine"},
+				{"id": "a2", "author_email": "other@example.com", "authored_date": "2023-11-13T10:00:00.000Z", "message": "Not mine"},
+			})
+			return
+		}
+		writeJSON(w, []map[string]string{
+			{"id": "a3", "author_email": "other@example.com", "authored_date": "2023-11-14T10:00:00.000Z", "message": "Pairing\n\nCo-authored-by: Tanuki <tanuki@example.com>"},
+		})
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGitLabClientProfile(t *testing.T) {
+	server := newGitLabTestServer(t)
+	client := NewGitLabClient(server.URL, "secret", false, nil)
+
+	profile, err := client.GetUserProfile(context.Background(), "")
+	if err != nil {
+		t.Fatalf("GetUserProfile failed: %v", err)
+	}
+
+	if profile.Username != "tanuki" {
+		t.Errorf("Expected username 'tanuki', got: %s", profile.Username)
+	}
+	if profile.CreatedAt.Year() != 2019 {
+		t.Errorf("Expected account created in 2019, got: %s", profile.CreatedAt)
+	}
+}
+
+func TestGitLabClientProfileOfAnotherUser(t *testing.T) {
+	server := newGitLabTestServer(t)
+	client := NewGitLabClient(server.URL, "secret", false, nil)
+
+	profile, err := client.GetUserProfile(context.Background(), "kitsune")
+	if err != nil {
+		t.Fatalf("GetUserProfile failed: %v", err)
+	}
+	if profile.Username != "kitsune" || profile.CreatedAt.Year() != 2016 {
+		t.Errorf("Expected kitsune created in 2016, got: %+v", profile)
+	}
+
+	if _, err := client.GetUserProfile(context.Background(), "nobody"); err == nil {
+		t.Error("Expected an error for an unknown user")
+	}
+}
+
+func TestGitLabClientLanguageStats(t *testing.T) {
+	server := newGitLabTestServer(t)
+	client := NewGitLabClient(server.URL, "secret", false, nil)
+
+	repos, err := client.GetRepositories(context.Background(), "tanuki")
+	if err != nil {
+		t.Fatalf("GetRepositories failed: %v", err)
+	}
+	languages := sumLanguages(repos)
+
+	// Percentages are converted to bytes using the repository size, or count
+	// as bytes without one; the fork is skipped
+	if languages["Go"] != 1540 || languages["Shell"] != 500 || languages["Python"] != 60 {
+		t.Errorf("Expected Go=1540, Shell=500 and Python=60, got: %v", languages)
+	}
+}
+
+func TestGitLabClientCommits(t *testing.T) {
+	server := newGitLabTestServer(t)
+	client := NewGitLabClient(server.URL, "secret", false, nil)
+
+	commits, err := client.GetAllCommits(context.Background(), "tanuki")
+	if err != nil {
+		t.Fatalf("GetAllCommits failed: %v", err)
+	}
+
+	if len(commits) != 2 {
+		t.Fatalf("Expected 2 attributed commits across both pages, got: %d", len(commits))
+	}
+	if commits[0].SHA != "a1" || commits[1].SHA != "a3" {
+		t.Errorf("Expected commits a1 and a3, got: %s and %s", commits[0].SHA, commits[1].SHA)
+	}
+	if commits[0].Date.Hour() != 9 {
+		t.Errorf("Expected the author's offset to be preserved, got: %s", commits[0].Date)
+	}
+}
+
+func TestGitLabClientUnauthorized(t *testing.T) {
+	server := newGitLabTestServer(t)
+	client := NewGitLabClient(server.URL, "wrong", false, nil)
+
+	if _, err := client.GetUsername(context.Background()); err == nil {
+		t.Error("Expected error for invalid token, got nil")
+	}
+}
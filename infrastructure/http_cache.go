@@ -0,0 +1,187 @@
+package infrastructure
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// HTTPCache is an http.RoundTripper that keeps GET responses on disk, keyed
+// by URL. Responses younger than the TTL are served without a request; older
+// ones are revalidated with If-None-Match / If-Modified-Since, and a 304 Not
+// Modified (which GitHub doesn't count against the rate limit) is answered
+// from the stored body.
+type HTTPCache struct {
+	base http.RoundTripper
+	dir  string
+	ttl  time.Duration
+}
+
+// cacheEntry is the on-disk representation of a cached response
+type cacheEntry struct {
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	StoredAt   time.Time   `json:"stored_at"`
+}
+
+// NewHTTPCache creates a cache in dir in front of base
+func NewHTTPCache(base http.RoundTripper, dir string, ttl time.Duration) *HTTPCache {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &HTTPCache{
+		base: base,
+		dir:  dir,
+		ttl:  ttl,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (c *HTTPCache) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return c.base.RoundTrip(req)
+	}
+
+	entry := c.load(req.URL.String())
+	if entry != nil && time.Since(entry.StoredAt) < c.ttl {
+		return entry.response(req, nil), nil
+	}
+
+	if entry != nil {
+		req = req.Clone(req.Context())
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := entry.Header.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := c.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		resp.Body.Close()
+		entry.StoredAt = time.Now()
+		c.store(entry)
+		return entry.response(req, resp.Header), nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.store(&cacheEntry{
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+		StoredAt:   time.Now(),
+	})
+	return resp, nil
+}
+
+// response rebuilds an http.Response from the entry. Stored rate limit
+// headers are stale, so only those of a 304 revalidation are passed on.
+func (e *cacheEntry) response(req *http.Request, fresh http.Header) *http.Response {
+	header := e.Header.Clone()
+	for key := range header {
+		if strings.HasPrefix(key, "X-Ratelimit-") {
+			delete(header, key)
+		}
+	}
+	for key, values := range fresh {
+		if strings.HasPrefix(key, "X-Ratelimit-") || key == "Date" || key == "Etag" {
+			header[key] = values
+		}
+	}
+	header.Set("X-From-Cache", "1")
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// path returns the file holding the entry for url
+func (c *HTTPCache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// load reads the entry for url, returning nil when missing or unreadable
+func (c *HTTPCache) load(url string) *cacheEntry {
+	data, err := os.ReadFile(c.path(url))
+	if err != nil {
+		return nil
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.URL != url {
+		return nil
+	}
+	return &entry
+}
+
+// store writes the entry atomically. The cache is best effort, so failures
+// are only logged.
+func (c *HTTPCache) store(entry *cacheEntry) {
+	if err := c.write(entry); err != nil {
+		log.Printf("Warning: failed to cache %s: %v\n", entry.URL, err)
+	}
+}
+
+func (c *HTTPCache) write(entry *cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.path(entry.URL))
+}
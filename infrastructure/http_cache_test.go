@@ -0,0 +1,113 @@
+package infrastructure
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newETagServer serves a fixed body with an ETag and answers matching
+// If-None-Match requests with 304 Not Modified
+func newETagServer(t *testing.T, requests, notModified *int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Link", `<https://api.github.com/user/repos?page=2>; rel="next"`)
+		w.Write([]byte(`[{"name": "repo"}]`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func getBody(t *testing.T, client *http.Client, url string) (*http.Response, string) {
+	t.Helper()
+
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read body: %v", err)
+	}
+	return resp, string(body)
+}
+
+func TestHTTPCacheRevalidatesWithETag(t *testing.T) {
+	var requests, notModified int32
+	server := newETagServer(t, &requests, &notModified)
+	client := &http.Client{Transport: NewHTTPCache(nil, t.TempDir(), 0)}
+
+	_, first := getBody(t, client, server.URL)
+	resp, second := getBody(t, client, server.URL)
+
+	if first != second || second != `[{"name": "repo"}]` {
+		t.Errorf("Expected cached body to be returned, got: %q and %q", first, second)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 304 to be served as 200, got: %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Link") == "" {
+		t.Error("Expected pagination headers to be restored from the cache")
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") != "4999" {
+		t.Error("Expected rate limit headers of the revalidation response")
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("Expected 2 requests with 1 conditional hit, got: %d and %d", requests, notModified)
+	}
+}
+
+func TestHTTPCacheServesFreshEntriesWithoutRequest(t *testing.T) {
+	var requests, notModified int32
+	server := newETagServer(t, &requests, &notModified)
+	client := &http.Client{Transport: NewHTTPCache(nil, t.TempDir(), time.Hour)}
+
+	getBody(t, client, server.URL)
+	resp, body := getBody(t, client, server.URL)
+
+	if body != `[{"name": "repo"}]` || resp.Header.Get("X-From-Cache") != "1" {
+		t.Errorf("Expected response from cache, got: %q", body)
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") != "" {
+		t.Error("Expected stale rate limit headers to be dropped")
+	}
+	if requests != 1 {
+		t.Errorf("Expected a single request within the TTL, got: %d", requests)
+	}
+}
+
+func TestHTTPCacheIgnoresNonGetRequests(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("ETag", `"v1"`)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewHTTPCache(nil, t.TempDir(), time.Hour)}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Post(server.URL, "application/json", nil)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if requests != 2 {
+		t.Errorf("Expected POST requests to bypass the cache, got: %d requests", requests)
+	}
+}
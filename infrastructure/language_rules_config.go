@@ -0,0 +1,59 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"GitInsights/domain"
+)
+
+// LoadLanguageRules reads a JSON file of the form
+//
+//	{
+//	  "groups": {"Styles": ["SCSS", "CSS", "Less"], "Python": ["Jupyter Notebook"]},
+//	  "renames": {"Vim Script": "Vim"},
+//	  "pinned": ["Rust"]
+//	}
+func LoadLanguageRules(path string) (domain.LanguageRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return domain.LanguageRules{}, fmt.Errorf("failed to read language rules: %w", err)
+	}
+
+	var config struct {
+		Groups  map[string][]string `json:"groups"`
+		Renames map[string]string   `json:"renames"`
+		Pinned  []string            `json:"pinned"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return domain.LanguageRules{}, fmt.Errorf("failed to parse language rules: %w", err)
+	}
+
+	rules := domain.LanguageRules{
+		Renames: config.Renames,
+		Pinned:  config.Pinned,
+	}
+
+	// Keep groups in a stable order and reject languages listed twice
+	names := make([]string, 0, len(config.Groups))
+	for name := range config.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	groupOf := make(map[string]string)
+	for _, name := range names {
+		for _, lang := range config.Groups[name] {
+			if other, ok := groupOf[strings.ToLower(lang)]; ok {
+				return domain.LanguageRules{}, fmt.Errorf("language %q is in both groups %q and %q", lang, other, name)
+			}
+			groupOf[strings.ToLower(lang)] = name
+		}
+		rules.Groups = append(rules.Groups, domain.LanguageGroup{Name: name, Languages: config.Groups[name]})
+	}
+
+	return rules, nil
+}
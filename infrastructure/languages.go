@@ -0,0 +1,90 @@
+package infrastructure
+
+import (
+	"path"
+	"strings"
+)
+
+// languageByExtension maps file extensions to the language names GitHub reports
+var languageByExtension = map[string]string{
+	".go":     "Go",
+	".js":     "JavaScript",
+	".mjs":    "JavaScript",
+	".cjs":    "JavaScript",
+	".jsx":    "JavaScript",
+	".ts":     "TypeScript",
+	".tsx":    "TypeScript",
+	".py":     "Python",
+	".ipynb":  "Jupyter Notebook",
+	".java":   "Java",
+	".rb":     "Ruby",
+	".php":    "PHP",
+	".c":      "C",
+	".h":      "C",
+	".cc":     "C++",
+	".cpp":    "C++",
+	".cxx":    "C++",
+	".hpp":    "C++",
+	".cs":     "C#",
+	".rs":     "Rust",
+	".swift":  "Swift",
+	".kt":     "Kotlin",
+	".kts":    "Kotlin",
+	".scala":  "Scala",
+	".ex":     "Elixir",
+	".exs":    "Elixir",
+	".erl":    "Erlang",
+	".html":   "HTML",
+	".htm":    "HTML",
+	".css":    "CSS",
+	".scss":   "SCSS",
+	".sass":   "Sass",
+	".less":   "Less",
+	".vue":    "Vue",
+	".svelte": "Svelte",
+	".sh":     "Shell",
+	".bash":   "Shell",
+	".zsh":    "Shell",
+	".vim":    "Vim Script",
+	".lua":    "Lua",
+	".dart":   "Dart",
+	".r":      "R",
+	".jl":     "Julia",
+	".hs":     "Haskell",
+	".pl":     "Perl",
+	".pm":     "Perl",
+	".m":      "Objective-C",
+	".mm":     "Objective-C++",
+	".sql":    "SQL",
+	".tf":     "HCL",
+	".hcl":    "HCL",
+	".clj":    "Clojure",
+	".fs":     "F#",
+	".ml":     "OCaml",
+	".zig":    "Zig",
+	".nix":    "Nix",
+	".ps1":    "PowerShell",
+	".groovy": "Groovy",
+}
+
+// languageByFilename maps well-known file names without a useful extension
+var languageByFilename = map[string]string{
+	"Dockerfile":  "Dockerfile",
+	"Makefile":    "Makefile",
+	"makefile":    "Makefile",
+	"GNUmakefile": "Makefile",
+	"Rakefile":    "Ruby",
+	"Gemfile":     "Ruby",
+}
+
+// detectLanguage returns the language of a file path, or "" when unknown
+func detectLanguage(filePath string) string {
+	base := path.Base(filePath)
+	if lang, ok := languageByFilename[base]; ok {
+		return lang
+	}
+	if strings.HasPrefix(base, "Dockerfile.") {
+		return "Dockerfile"
+	}
+	return languageByExtension[strings.ToLower(path.Ext(base))]
+}
@@ -0,0 +1,239 @@
+package infrastructure
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Linguist's built-in path rules, used unless .gitattributes says otherwise.
+// These are the most common entries of Linguist's vendor.yml,
+// documentation.yml and generated file checks.
+var (
+	vendoredPaths = regexp.MustCompile(`(^|/)(vendor|node_modules|bower_components|third[-_]?party|Godeps/_workspace|dist)/` +
+		`|\.min\.(js|css)$|(^|/)jquery[^/]*\.js$`)
+	documentationPaths = regexp.MustCompile(`^[Dd]ocs?/|(^|/)[Dd]ocumentation/|(^|/)[Ee]xamples?/` +
+		`|(^|/)(CHANGE(S|LOG)?|CONTRIBUTING|COPYING|INSTALL|LICEN[CS]E|README)(\.[^/]*)?$`)
+	generatedPaths = regexp.MustCompile(`\.pb\.(go|cc|h)$|\.pb\.gw\.go$|_pb2(_grpc)?\.py$|_pb\.(js|d\.ts)$` +
+		`|\.g\.dart$|\.designer\.cs$|(^|/)package-lock\.json$`)
+)
+
+// linguistAttributes are the attributes Linguist reads from .gitattributes
+var linguistAttributes = map[string]bool{
+	"linguist-vendored":      true,
+	"linguist-generated":     true,
+	"linguist-documentation": true,
+	"linguist-language":      true,
+}
+
+// attributeRule is one .gitattributes line: a path pattern and the linguist
+// attributes it sets ("true", "false" or a language name)
+type attributeRule struct {
+	pattern *regexp.Regexp
+	attrs   map[string]string
+}
+
+// gitAttributes holds the linguist overrides of every .gitattributes file in
+// a tree. Later rules override earlier ones, and files deeper in the tree
+// override shallower ones, like git itself.
+type gitAttributes struct {
+	files map[string]string // directory ("" for the root) -> file content
+}
+
+// newGitAttributes creates an empty set of overrides
+func newGitAttributes() *gitAttributes {
+	return &gitAttributes{files: make(map[string]string)}
+}
+
+// addFile registers the content of the .gitattributes file at filePath
+func (a *gitAttributes) addFile(filePath, content string) {
+	dir := path.Dir(filePath)
+	if dir == "." {
+		dir = ""
+	}
+	a.files[dir] = content
+}
+
+// rules parses every file, shallowest directory first
+func (a *gitAttributes) rules() []attributeRule {
+	dirs := make([]string, 0, len(a.files))
+	for dir := range a.files {
+		dirs = append(dirs, dir)
+	}
+	depth := func(dir string) int {
+		if dir == "" {
+			return 0
+		}
+		return strings.Count(dir, "/") + 1
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		if depth(dirs[i]) != depth(dirs[j]) {
+			return depth(dirs[i]) < depth(dirs[j])
+		}
+		return dirs[i] < dirs[j]
+	})
+
+	var rules []attributeRule
+	for _, dir := range dirs {
+		rules = append(rules, parseGitAttributes(dir, a.files[dir])...)
+	}
+	return rules
+}
+
+// parseGitAttributes returns the rules of one file that set linguist
+// attributes. Macros, negated patterns and directory patterns are skipped, as
+// git ignores them in attribute files.
+func parseGitAttributes(dir, content string) []attributeRule {
+	var rules []attributeRule
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "[attr]") {
+			continue
+		}
+		pattern := fields[0]
+		if strings.HasPrefix(pattern, "!") || strings.HasSuffix(pattern, "/") {
+			continue
+		}
+
+		attrs := make(map[string]string)
+		for _, field := range fields[1:] {
+			name, value := field, "true"
+			switch {
+			case strings.HasPrefix(field, "-"):
+				name, value = field[1:], "false"
+			case strings.HasPrefix(field, "!"):
+				name, value = field[1:], ""
+			case strings.Contains(field, "="):
+				name, value, _ = strings.Cut(field, "=")
+			}
+			if linguistAttributes[name] {
+				attrs[name] = value
+			}
+		}
+		if len(attrs) == 0 {
+			continue
+		}
+
+		rules = append(rules, attributeRule{
+			pattern: compileAttributePattern(dir, pattern),
+			attrs:   attrs,
+		})
+	}
+	return rules
+}
+
+// compileAttributePattern turns a gitattributes pattern of the file in dir
+// into a regular expression over paths from the repository root. Patterns
+// without a slash match the file name at any depth below dir.
+func compileAttributePattern(dir, pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("^")
+	if dir != "" {
+		expr.WriteString(regexp.QuoteMeta(dir + "/"))
+	}
+	if !strings.Contains(pattern, "/") {
+		expr.WriteString("(?:.*/)?")
+	}
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**") && i+2 == len(pattern):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				expr.WriteString(`\[`)
+				continue
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		// A malformed character class never matches, like in git
+		return regexp.MustCompile(`$^`)
+	}
+	return re
+}
+
+// linguistClassifier decides which language each file of a tree counts as
+type linguistClassifier struct {
+	rules []attributeRule
+}
+
+// newLinguistClassifier creates a classifier for the given overrides
+func newLinguistClassifier(attributes *gitAttributes) *linguistClassifier {
+	return &linguistClassifier{rules: attributes.rules()}
+}
+
+// language returns the language a file counts towards in the statistics, or
+// "" when it is vendored, generated, documentation or not a known language
+func (c *linguistClassifier) language(filePath string) string {
+	attrs := make(map[string]string)
+	for _, rule := range c.rules {
+		if !rule.pattern.MatchString(filePath) {
+			continue
+		}
+		for name, value := range rule.attrs {
+			if value == "" {
+				delete(attrs, name)
+			} else {
+				attrs[name] = value
+			}
+		}
+	}
+
+	excluded := func(name string, builtIn *regexp.Regexp) bool {
+		if value, ok := attrs[name]; ok {
+			return value != "false"
+		}
+		return builtIn.MatchString(filePath)
+	}
+	if excluded("linguist-vendored", vendoredPaths) ||
+		excluded("linguist-generated", generatedPaths) ||
+		excluded("linguist-documentation", documentationPaths) {
+		return ""
+	}
+
+	if lang := attrs["linguist-language"]; lang != "" && lang != "true" && lang != "false" {
+		return canonicalLanguage(lang)
+	}
+	return detectLanguage(filePath)
+}
+
+// canonicalLanguage spells a linguist-language value the way GitHub reports
+// it. Linguist matches names case-insensitively and uses dashes for spaces.
+func canonicalLanguage(name string) string {
+	normalize := func(lang string) string {
+		return strings.ToLower(strings.ReplaceAll(lang, "-", " "))
+	}
+
+	wanted := normalize(name)
+	for _, languages := range []map[string]string{languageByExtension, languageByFilename} {
+		for _, lang := range languages {
+			if normalize(lang) == wanted {
+				return lang
+			}
+		}
+	}
+	return name
+}
@@ -0,0 +1,78 @@
+package infrastructure
+
+import "testing"
+
+func TestCompileAttributePattern(t *testing.T) {
+	tests := []struct {
+		dir, pattern, path string
+		want               bool
+	}{
+		{"", "*.js", "app.js", true},
+		{"", "*.js", "web/static/app.js", true},
+		{"", "*.js", "app.json", false},
+		{"", "/gen/*.go", "gen/api.go", true},
+		{"", "/gen/*.go", "gen/sub/api.go", false},
+		{"", "/gen/*.go", "pkg/gen/api.go", false},
+		{"", "assets/**", "assets/js/lib/d3.js", true},
+		{"", "**/fixtures/*", "pkg/test/fixtures/data.rb", true},
+		{"", "**/fixtures/*", "fixtures/data.rb", true},
+		{"", "src/**/*.ts", "src/a/b/c.ts", true},
+		{"", "src/**/*.ts", "src/c.ts", true},
+		{"", "file?.[ch]", "lib/file1.c", true},
+		{"", "file?.[!ch]", "lib/file1.c", false},
+		{"web", "*.js", "web/app.js", true},
+		{"web", "*.js", "app.js", false},
+		{"web", "/vendor/*", "web/vendor/lib.js", true},
+	}
+
+	for _, tt := range tests {
+		re := compileAttributePattern(tt.dir, tt.pattern)
+		if got := re.MatchString(tt.path); got != tt.want {
+			t.Errorf("pattern %q in %q matching %q: expected %v, got %v", tt.pattern, tt.dir, tt.path, tt.want, got)
+		}
+	}
+}
+
+func TestLinguistClassifierOverrides(t *testing.T) {
+	attributes := newGitAttributes()
+	attributes.addFile(".gitattributes", `
+# comments and unrelated attributes are ignored
+*.sh text eol=lf
+static/** linguist-vendored
+static/app/** -linguist-vendored
+api/*.pb.go -linguist-generated
+*.rb linguist-generated=true
+docs/** -linguist-documentation
+*.inc linguist-language=PHP
+*.tmpl linguist-language=objective-c
+`)
+	attributes.addFile("legacy/.gitattributes", `*.js linguist-vendored`)
+	attributes.addFile("legacy/keep/.gitattributes", `*.js !linguist-vendored`)
+
+	classifier := newLinguistClassifier(attributes)
+	tests := map[string]string{
+		"main.go":                 "Go",
+		"deploy.sh":               "Shell",
+		"static/lib/jquery.js":    "",
+		"static/app/main.js":      "JavaScript",
+		"node_modules/x/index.js": "",
+		"web/app.min.js":          "",
+		"proto/user.pb.go":        "",
+		"api/user.pb.go":          "Go",
+		"lib/model.rb":            "",
+		"docs/example.py":         "Python",
+		"examples/demo.py":        "",
+		"README.md":               "",
+		"header.inc":              "PHP",
+		"view.tmpl":               "Objective-C",
+		"legacy/old.js":           "",
+		"legacy/keep/new.js":      "JavaScript",
+		"image.png":               "",
+	}
+
+	for filePath, want := range tests {
+		if got := classifier.language(filePath); got != want {
+			t.Errorf("%s: expected %q, got %q", filePath, want, got)
+		}
+	}
+}
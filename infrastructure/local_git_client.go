@@ -0,0 +1,233 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"GitInsights/domain"
+)
+
+// LocalGitClient implements domain.GitHubRepository on top of local clones,
+// reading history through `git log` so no token or network access is needed
+type LocalGitClient struct {
+	repoPaths    []string
+	authorEmails []string
+}
+
+// NewLocalGitClient creates a client for the given repository paths. Commits
+// are attributed to the configured git user.email and the extra authorEmails.
+func NewLocalGitClient(repoPaths []string, authorEmails []string) *LocalGitClient {
+	return &LocalGitClient{
+		repoPaths:    repoPaths,
+		authorEmails: authorEmails,
+	}
+}
+
+// GetUsername returns the configured git user.name of the first repository
+func (l *LocalGitClient) GetUsername(ctx context.Context) (string, error) {
+	if len(l.repoPaths) == 0 {
+		return "", fmt.Errorf("no local repositories configured")
+	}
+
+	name, err := l.git(ctx, l.repoPaths[0], "config", "user.name")
+	if err != nil {
+		return "", fmt.Errorf("failed to read git user.name: %w", err)
+	}
+
+	username := strings.TrimSpace(string(name))
+	if username == "" {
+		return "", fmt.Errorf("git user.name is empty")
+	}
+
+	return username, nil
+}
+
+// GetUserProfile uses the user's earliest local commit as the account creation
+// date. Local repositories have no accounts, so username is ignored and the
+// configured git identity is used.
+func (l *LocalGitClient) GetUserProfile(ctx context.Context, _ string) (*domain.UserProfile, error) {
+	username, err := l.GetUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt := time.Now()
+	for _, repoPath := range l.repoPaths {
+		first, err := l.firstCommitDate(ctx, repoPath)
+		if err != nil {
+			return nil, err
+		}
+		if !first.IsZero() && first.Before(createdAt) {
+			createdAt = first
+		}
+	}
+
+	return &domain.UserProfile{
+		Username:  username,
+		CreatedAt: createdAt,
+	}, nil
+}
+
+// GetRepositories sums the size of tracked files per language of every
+// repository, detected from file extensions. The last commit stands in for
+// the last push.
+func (l *LocalGitClient) GetRepositories(ctx context.Context, username string) ([]domain.Repository, error) {
+	log.Printf("Analyzing languages across %d local repositories...\n", len(l.repoPaths))
+
+	var repositories []domain.Repository
+	for _, repoPath := range l.repoPaths {
+		languages := make(map[string]int)
+		output, err := l.git(ctx, repoPath, "ls-files", "-z")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files in %s: %w", repoPath, err)
+		}
+
+		for _, file := range strings.Split(string(output), "\x00") {
+			lang := detectLanguage(file)
+			if lang == "" {
+				continue
+			}
+
+			info, err := os.Lstat(filepath.Join(repoPath, file))
+			if err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			languages[lang] += int(info.Size())
+		}
+
+		repository := domain.Repository{
+			Name:      filepath.Base(repoPath),
+			Languages: languages,
+		}
+		// Repositories without commits have no date
+		if output, err := l.git(ctx, repoPath, "log", "-1", "--format=%cI"); err == nil {
+			repository.PushedAt, _ = time.Parse(time.RFC3339, strings.TrimSpace(string(output)))
+		}
+		repositories = append(repositories, repository)
+	}
+
+	return repositories, nil
+}
+
+// GetAllCommits reads the history of every repository and keeps the user's commits
+func (l *LocalGitClient) GetAllCommits(ctx context.Context, username string) ([]domain.Commit, error) {
+	log.Printf("Fetching commits from %d local repositories...\n", len(l.repoPaths))
+
+	var allCommits []domain.Commit
+	for _, repoPath := range l.repoPaths {
+		commits, err := l.repositoryCommits(ctx, repoPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(commits) > 0 {
+			log.Printf("  ✓ %s: %d commits\n", filepath.Base(repoPath), len(commits))
+		}
+		allCommits = append(allCommits, commits...)
+	}
+
+	log.Printf("Total commits analyzed: %d\n", len(allCommits))
+	return allCommits, nil
+}
+
+// firstCommitDate returns the earliest author date of the user's commits in
+// a repository, or zero without any. Only the dates are read, filtered by git;
+// author dates needn't follow the history, so every one is compared.
+func (l *LocalGitClient) firstCommitDate(ctx context.Context, repoPath string) (time.Time, error) {
+	args := []string{"log", "--format=%aI", "--fixed-strings", "--regexp-ignore-case"}
+	authors := 0
+	for _, email := range l.emails(ctx, repoPath) {
+		if email != "" {
+			args = append(args, "--author=<"+email+">")
+			authors++
+		}
+	}
+	if authors == 0 {
+		return time.Time{}, nil
+	}
+
+	output, err := l.git(ctx, repoPath, args...)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read history of %s: %w", repoPath, err)
+	}
+
+	var first time.Time
+	for _, line := range strings.Fields(string(output)) {
+		date, err := time.Parse(time.RFC3339, line)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse commit date %q: %w", line, err)
+		}
+		if first.IsZero() || date.Before(first) {
+			first = date
+		}
+	}
+	return first, nil
+}
+
+// emails returns the author emails attributed to the user in a repository:
+// the configured ones and its git user.email
+func (l *LocalGitClient) emails(ctx context.Context, repoPath string) []string {
+	emails := append([]string{}, l.authorEmails...)
+	if email, err := l.git(ctx, repoPath, "config", "user.email"); err == nil {
+		emails = append(emails, strings.TrimSpace(string(email)))
+	}
+	return emails
+}
+
+// repositoryCommits parses `git log` output for a single repository
+func (l *LocalGitClient) repositoryCommits(ctx context.Context, repoPath string) ([]domain.Commit, error) {
+	matcher := NewAuthorMatcher("", l.emails(ctx, repoPath))
+
+	// Fields are separated by US (0x1f) and records by RS (0x1e)
+	output, err := l.git(ctx, repoPath, "log", "--format=%H%x1f%aI%x1f%ae%x1f%B%x1e")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history of %s: %w", repoPath, err)
+	}
+
+	var commits []domain.Commit
+	for _, record := range strings.Split(string(output), "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x1f", 4)
+		if len(fields) != 4 {
+			continue
+		}
+
+		attribution, ok := matcher.Match("", fields[2], fields[3])
+		if !ok {
+			continue
+		}
+
+		date, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse commit date %q: %w", fields[1], err)
+		}
+
+		commits = append(commits, domain.Commit{
+			SHA:         fields[0],
+			Date:        date,
+			Attribution: attribution,
+			Repository:  filepath.Base(repoPath),
+		})
+	}
+
+	return commits, nil
+}
+
+// git runs a git command inside the given repository and returns its stdout
+func (l *LocalGitClient) git(ctx context.Context, repoPath string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repoPath}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return output, nil
+}
@@ -0,0 +1,146 @@
+package infrastructure
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"GitInsights/domain"
+)
+
+// initTestRepo creates a git repository with one commit by the configured
+// user and one by somebody else
+func initTestRepo(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(env []string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), env...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	run(nil, "init", "-q")
+	run(nil, "config", "user.name", "Test User")
+	run(nil, "config", "user.email", "test@example.com")
+	run(nil, "config", "commit.gpgsign", "false")
+
+	files := map[string]string{
+		"main.go":   "package main\n\nfunc main() {}\n",
+		"script.py": "print('hi')\n",
+		"notes.txt": "not a language\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	run([]string{"GIT_AUTHOR_DATE=2023-11-13T09:15:00+07:00"}, "add", ".")
+	run([]string{"GIT_AUTHOR_DATE=2023-11-13T09:15:00+07:00"}, "commit", "-q", "-m", "Initial commit")
+	run([]string{
+		"GIT_AUTHOR_NAME=Someone Else",
+		"GIT_AUTHOR_EMAIL=else@example.com",
+		"GIT_AUTHOR_DATE=2023-11-14T10:00:00+00:00",
+	}, "commit", "-q", "--allow-empty", "-m", "Not mine")
+
+	return dir
+}
+
+func TestLocalGitClientCommits(t *testing.T) {
+	repo := initTestRepo(t)
+	client := NewLocalGitClient([]string{repo}, nil)
+	ctx := context.Background()
+
+	username, err := client.GetUsername(ctx)
+	if err != nil {
+		t.Fatalf("GetUsername failed: %v", err)
+	}
+	if username != "Test User" {
+		t.Errorf("Expected username 'Test User', got: %s", username)
+	}
+
+	commits, err := client.GetAllCommits(ctx, username)
+	if err != nil {
+		t.Fatalf("GetAllCommits failed: %v", err)
+	}
+
+	if len(commits) != 1 {
+		t.Fatalf("Expected 1 commit by the configured user, got: %d", len(commits))
+	}
+
+	commit := commits[0]
+	if commit.Attribution != domain.AttributedToEmail {
+		t.Errorf("Expected attribution by email, got: %s", commit.Attribution)
+	}
+	if len(commit.SHA) != 40 {
+		t.Errorf("Expected a full commit SHA, got: %q", commit.SHA)
+	}
+
+	// The committer's own offset must be preserved
+	if _, offset := commit.Date.Zone(); offset != 7*60*60 || commit.Date.Hour() != 9 {
+		t.Errorf("Expected commit at 09:15 +07:00, got: %s", commit.Date.Format(time.RFC3339))
+	}
+}
+
+func TestLocalGitClientProfile(t *testing.T) {
+	repo := initTestRepo(t)
+	ctx := context.Background()
+
+	// A commit dated before the history, by a second identity
+	cmd := exec.Command("git", "-C", repo, "commit", "-q", "--allow-empty", "-m", "Backdated")
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=Old Me", "GIT_AUTHOR_EMAIL=old@example.com", "GIT_AUTHOR_DATE=2020-02-03T10:00:00+00:00")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git commit failed: %v\n%s", err, output)
+	}
+
+	profile, err := NewLocalGitClient([]string{repo}, nil).GetUserProfile(ctx, "")
+	if err != nil {
+		t.Fatalf("GetUserProfile failed: %v", err)
+	}
+	if profile.Username != "Test User" || !profile.CreatedAt.Equal(time.Date(2023, 11, 13, 2, 15, 0, 0, time.UTC)) {
+		t.Errorf("Expected Test User's first commit on 2023-11-13, got: %+v", profile)
+	}
+
+	profile, err = NewLocalGitClient([]string{repo}, []string{"OLD@example.com"}).GetUserProfile(ctx, "")
+	if err != nil {
+		t.Fatalf("GetUserProfile failed: %v", err)
+	}
+	if !profile.CreatedAt.Equal(time.Date(2020, 2, 3, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the backdated commit of the extra email, got: %s", profile.CreatedAt)
+	}
+}
+
+func TestLocalGitClientLanguageStats(t *testing.T) {
+	repo := initTestRepo(t)
+	client := NewLocalGitClient([]string{repo}, nil)
+
+	repos, err := client.GetRepositories(context.Background(), "Test User")
+	if err != nil {
+		t.Fatalf("GetRepositories failed: %v", err)
+	}
+	if len(repos) != 1 || repos[0].PushedAt.IsZero() {
+		t.Fatalf("Expected one repository dated by its last commit, got: %+v", repos)
+	}
+	languages := sumLanguages(repos)
+
+	if languages["Go"] != len("package main\n\nfunc main() {}\n") {
+		t.Errorf("Expected Go bytes to match main.go size, got: %d", languages["Go"])
+	}
+	if languages["Python"] != len("print('hi')\n") {
+		t.Errorf("Expected Python bytes to match script.py size, got: %d", languages["Python"])
+	}
+	if len(languages) != 2 {
+		t.Errorf("Expected only Go and Python, got: %v", languages)
+	}
+}
@@ -0,0 +1,201 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"GitInsights/domain"
+)
+
+// MultiSourceRepository implements domain.GitHubRepository by fanning out to
+// several sources (github.com, GitHub Enterprise, GitLab, Gitea, local clones)
+// and merging their results into one footprint. Each source resolves its own
+// identity, so the username passed to the aggregate methods is ignored.
+type MultiSourceRepository struct {
+	sources []domain.GitHubRepository
+}
+
+// NewMultiSourceRepository creates a repository merging the given sources.
+// The first source is the primary identity shown in the profile.
+func NewMultiSourceRepository(sources ...domain.GitHubRepository) *MultiSourceRepository {
+	return &MultiSourceRepository{
+		sources: sources,
+	}
+}
+
+// GetUsername returns the username of the primary source
+func (m *MultiSourceRepository) GetUsername(ctx context.Context) (string, error) {
+	if len(m.sources) == 0 {
+		return "", fmt.Errorf("no sources configured")
+	}
+	return m.sources[0].GetUsername(ctx)
+}
+
+// usernameFor returns the account to analyze on source i: the requested
+// username on the primary source, and the source's own account elsewhere
+func (m *MultiSourceRepository) usernameFor(ctx context.Context, i int, source domain.GitHubRepository, username string) (string, error) {
+	if i == 0 && username != "" {
+		return username, nil
+	}
+	return source.GetUsername(ctx)
+}
+
+// GetUserProfile uses the primary username and the oldest account across all sources
+func (m *MultiSourceRepository) GetUserProfile(ctx context.Context, username string) (*domain.UserProfile, error) {
+	profiles := make([]*domain.UserProfile, len(m.sources))
+	err := m.fanOut(func(i int, source domain.GitHubRepository) error {
+		sourceUsername := ""
+		if i == 0 {
+			sourceUsername = username
+		}
+		profile, err := source.GetUserProfile(ctx, sourceUsername)
+		profiles[i] = profile
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	merged := *profiles[0]
+	for _, profile := range profiles[1:] {
+		if profile.CreatedAt.Before(merged.CreatedAt) {
+			merged.CreatedAt = profile.CreatedAt
+		}
+	}
+	return &merged, nil
+}
+
+// GetRepositories concatenates the repositories of every source
+func (m *MultiSourceRepository) GetRepositories(ctx context.Context, username string) ([]domain.Repository, error) {
+	results := make([][]domain.Repository, len(m.sources))
+	err := m.fanOut(func(i int, source domain.GitHubRepository) error {
+		sourceUsername, err := m.usernameFor(ctx, i, source, username)
+		if err != nil {
+			return err
+		}
+		results[i], err = source.GetRepositories(ctx, sourceUsername)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var repositories []domain.Repository
+	for _, repos := range results {
+		repositories = append(repositories, repos...)
+	}
+	return repositories, nil
+}
+
+// GetAllCommits merges the commits of every source. Commits with a SHA are
+// de-duplicated by it, so mirrors of the same repository count once.
+// Date-only commits, such as GraphQL contributions, can't be matched, so each
+// day counts as many of them as the largest of the sources' own count and
+// the commits with a SHA on that day.
+func (m *MultiSourceRepository) GetAllCommits(ctx context.Context, username string) ([]domain.Commit, error) {
+	results := make([][]domain.Commit, len(m.sources))
+	err := m.fanOut(func(i int, source domain.GitHubRepository) error {
+		sourceUsername, err := m.usernameFor(ctx, i, source, username)
+		if err != nil {
+			return err
+		}
+		results[i], err = source.GetAllCommits(ctx, sourceUsername)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	allCommits, duplicates := mergeCommits(results)
+	log.Printf("Merged %d commits from %d sources (%d duplicates skipped)\n", len(allCommits), len(m.sources), duplicates)
+	return allCommits, nil
+}
+
+// mergeCommits merges the commits of several sources and returns them with
+// the number of duplicates left out
+func mergeCommits(results [][]domain.Commit) ([]domain.Commit, int) {
+	const layout = "2006-01-02"
+
+	seen := make(map[string]bool)
+	withSHA := make(map[string]int)
+	var allCommits []domain.Commit
+	duplicates := 0
+
+	// Date-only commits of each source, by day
+	dateOnly := make([]map[string][]domain.Commit, len(results))
+	for i, commits := range results {
+		dateOnly[i] = make(map[string][]domain.Commit)
+		for _, commit := range commits {
+			switch {
+			case commit.SHA == "" && commit.DateOnly:
+				day := commit.Date.Format(layout)
+				dateOnly[i][day] = append(dateOnly[i][day], commit)
+			case commit.SHA == "":
+				allCommits = append(allCommits, commit)
+			case seen[commit.SHA]:
+				duplicates++
+			default:
+				seen[commit.SHA] = true
+				withSHA[commit.Date.Format(layout)]++
+				allCommits = append(allCommits, commit)
+			}
+		}
+	}
+
+	// Per day, the source with the most date-only commits tops up the count
+	days := make(map[string][]domain.Commit)
+	for _, byDay := range dateOnly {
+		for day, commits := range byDay {
+			if len(commits) > len(days[day]) {
+				days[day] = commits
+			}
+		}
+	}
+	for _, byDay := range dateOnly {
+		for _, commits := range byDay {
+			duplicates += len(commits)
+		}
+	}
+	for day, commits := range days {
+		extra := len(commits) - withSHA[day]
+		if extra > 0 {
+			allCommits = append(allCommits, commits[:extra]...)
+			duplicates -= extra
+		}
+	}
+
+	sort.SliceStable(allCommits, func(i, j int) bool {
+		return allCommits[i].Date.Before(allCommits[j].Date)
+	})
+	return allCommits, duplicates
+}
+
+// fanOut calls fn for every source concurrently and returns the first error
+func (m *MultiSourceRepository) fanOut(fn func(i int, source domain.GitHubRepository) error) error {
+	if len(m.sources) == 0 {
+		return fmt.Errorf("no sources configured")
+	}
+
+	errs := make([]error, len(m.sources))
+	var wg sync.WaitGroup
+	for i, source := range m.sources {
+		wg.Add(1)
+		go func(i int, source domain.GitHubRepository) {
+			defer wg.Done()
+			if err := fn(i, source); err != nil {
+				errs[i] = fmt.Errorf("source %d: %w", i+1, err)
+			}
+		}(i, source)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"GitInsights/domain"
+)
+
+// stubSource is an in-memory domain.GitHubRepository
+type stubSource struct {
+	username  string
+	createdAt time.Time
+	languages map[string]int
+	commits   []domain.Commit
+	err       error
+}
+
+func (s *stubSource) GetUsername(ctx context.Context) (string, error) {
+	return s.username, s.err
+}
+
+func (s *stubSource) GetUserProfile(ctx context.Context, username string) (*domain.UserProfile, error) {
+	return &domain.UserProfile{Username: s.username, CreatedAt: s.createdAt}, s.err
+}
+
+func (s *stubSource) GetRepositories(ctx context.Context, username string) ([]domain.Repository, error) {
+	if username != s.username {
+		return nil, errors.New("unexpected username " + username)
+	}
+	return []domain.Repository{{Owner: s.username, Name: "repo", Languages: s.languages}}, s.err
+}
+
+func (s *stubSource) GetAllCommits(ctx context.Context, username string) ([]domain.Commit, error) {
+	if username != s.username {
+		return nil, errors.New("unexpected username " + username)
+	}
+	return s.commits, s.err
+}
+
+func TestMultiSourceRepositoryMergesSources(t *testing.T) {
+	day := time.Date(2023, 11, 13, 9, 0, 0, 0, time.UTC)
+	github := &stubSource{
+		username:  "octocat",
+		createdAt: time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
+		languages: map[string]int{"Go": 1000, "Shell": 100},
+		commits:   []domain.Commit{{SHA: "a", Date: day}, {SHA: "b", Date: day}},
+	}
+	gitlab := &stubSource{
+		username:  "octo-at-work",
+		createdAt: time.Date(2012, 6, 1, 0, 0, 0, 0, time.UTC),
+		languages: map[string]int{"Go": 500, "Ruby": 300},
+		// "b" is a mirror of the same commit
+		commits: []domain.Commit{{SHA: "b", Date: day}, {SHA: "c", Date: day}, {Date: day}},
+	}
+
+	repo := NewMultiSourceRepository(github, gitlab)
+	ctx := context.Background()
+
+	username, err := repo.GetUsername(ctx)
+	if err != nil || username != "octocat" {
+		t.Errorf("Expected primary username 'octocat', got: %q (%v)", username, err)
+	}
+
+	profile, err := repo.GetUserProfile(ctx, "")
+	if err != nil {
+		t.Fatalf("GetUserProfile failed: %v", err)
+	}
+	if profile.Username != "octocat" || profile.CreatedAt.Year() != 2012 {
+		t.Errorf("Expected octocat with the oldest account date, got: %+v", profile)
+	}
+
+	repos, err := repo.GetRepositories(ctx, username)
+	if err != nil {
+		t.Fatalf("GetRepositories failed: %v", err)
+	}
+	languages := sumLanguages(repos)
+	if languages["Go"] != 1500 || languages["Shell"] != 100 || languages["Ruby"] != 300 {
+		t.Errorf("Expected summed languages, got: %v", languages)
+	}
+
+	commits, err := repo.GetAllCommits(ctx, username)
+	if err != nil {
+		t.Fatalf("GetAllCommits failed: %v", err)
+	}
+	if len(commits) != 4 {
+		t.Errorf("Expected 4 commits after de-duplicating by SHA, got: %d", len(commits))
+	}
+}
+
+func TestMultiSourceRepositoryMergesDateOnlyCommits(t *testing.T) {
+	monday := time.Date(2023, 11, 13, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	dateOnly := func(date time.Time, count int) []domain.Commit {
+		commits := make([]domain.Commit, count)
+		for i := range commits {
+			commits[i] = domain.Commit{Date: date, DateOnly: true}
+		}
+		return commits
+	}
+
+	// The REST source sees the same GitHub commits as the GraphQL ones
+	rest := &stubSource{
+		username: "octocat",
+		commits:  []domain.Commit{{SHA: "a", Date: monday.Add(9 * time.Hour)}, {SHA: "b", Date: monday.Add(10 * time.Hour)}},
+	}
+	graphql := &stubSource{username: "octocat", commits: append(dateOnly(monday, 3), dateOnly(tuesday, 1)...)}
+	work := &stubSource{username: "octo-at-work", commits: append(dateOnly(monday, 1), dateOnly(tuesday, 2)...)}
+
+	commits, err := NewMultiSourceRepository(rest, graphql, work).GetAllCommits(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("GetAllCommits failed: %v", err)
+	}
+
+	perDay := make(map[time.Time]int)
+	withSHA := 0
+	for _, commit := range commits {
+		perDay[commit.Date.Truncate(24*time.Hour)]++
+		if commit.SHA != "" {
+			withSHA++
+		}
+	}
+	if len(commits) != 5 || perDay[monday] != 3 || perDay[tuesday] != 2 {
+		t.Errorf("Expected the largest count per day (3 on Monday, 2 on Tuesday), got: %v", perDay)
+	}
+	if withSHA != 2 {
+		t.Errorf("Expected both commits with a SHA to be kept, got: %d", withSHA)
+	}
+}
+
+func TestMultiSourceRepositoryPropagatesErrors(t *testing.T) {
+	repo := NewMultiSourceRepository(
+		&stubSource{username: "octocat"},
+		&stubSource{username: "broken", err: errors.New("boom")},
+	)
+
+	if _, err := repo.GetAllCommits(context.Background(), "octocat"); err == nil {
+		t.Error("Expected error from failing source, got nil")
+	}
+}
@@ -0,0 +1,162 @@
+package infrastructure
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"strings"
+
+	"github.com/google/go-github/v38/github"
+)
+
+// RepositoryFilterConfig selects which GitHub repositories are analyzed.
+// Patterns are shell globs matched case-insensitively against the repository
+// name, or against owner/name when they contain a slash.
+type RepositoryFilterConfig struct {
+	IncludeRepos     []string `json:"include_repos,omitempty"`
+	ExcludeRepos     []string `json:"exclude_repos,omitempty"`
+	ExcludeArchived  bool     `json:"exclude_archived,omitempty"`
+	ExcludeTemplates bool     `json:"exclude_templates,omitempty"`
+	ExcludePrivate   bool     `json:"exclude_private,omitempty"`
+	ExcludeTopics    []string `json:"exclude_topics,omitempty"`
+}
+
+// Validate checks that every pattern is a well-formed glob
+func (c RepositoryFilterConfig) Validate() error {
+	for _, pattern := range append(append([]string{}, c.IncludeRepos...), c.ExcludeRepos...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid repository pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// IsEmpty reports whether no filter is configured
+func (c RepositoryFilterConfig) IsEmpty() bool {
+	return len(c.IncludeRepos) == 0 && len(c.ExcludeRepos) == 0 && len(c.ExcludeTopics) == 0 &&
+		!c.ExcludeArchived && !c.ExcludeTemplates && !c.ExcludePrivate
+}
+
+// repositoryRule excludes the repositories its predicate matches
+type repositoryRule struct {
+	name    string
+	exclude func(repo *github.Repository) bool
+}
+
+// RepositoryFilter is a chain of rules; a repository is analyzed only when
+// no rule excludes it
+type RepositoryFilter struct {
+	rules []repositoryRule
+}
+
+// NewRepositoryFilter builds the rule chain for cfg, excluding forks unless
+// includeForks is set
+func NewRepositoryFilter(cfg RepositoryFilterConfig, includeForks bool) *RepositoryFilter {
+	f := &RepositoryFilter{}
+
+	if !includeForks {
+		f.add("fork", func(repo *github.Repository) bool {
+			return repo.GetFork()
+		})
+	}
+	if cfg.ExcludeArchived {
+		f.add("archived", func(repo *github.Repository) bool {
+			return repo.GetArchived()
+		})
+	}
+	if cfg.ExcludeTemplates {
+		f.add("template", func(repo *github.Repository) bool {
+			return repo.GetIsTemplate()
+		})
+	}
+	if cfg.ExcludePrivate {
+		f.add("private", func(repo *github.Repository) bool {
+			return repo.GetPrivate()
+		})
+	}
+	if len(cfg.IncludeRepos) > 0 {
+		f.add("not in include-repos", func(repo *github.Repository) bool {
+			return !matchesAnyPattern(repo, cfg.IncludeRepos)
+		})
+	}
+	if len(cfg.ExcludeRepos) > 0 {
+		f.add("exclude-repos", func(repo *github.Repository) bool {
+			return matchesAnyPattern(repo, cfg.ExcludeRepos)
+		})
+	}
+	if len(cfg.ExcludeTopics) > 0 {
+		f.add("topic", func(repo *github.Repository) bool {
+			for _, topic := range repo.Topics {
+				for _, excluded := range cfg.ExcludeTopics {
+					if strings.EqualFold(topic, excluded) {
+						return true
+					}
+				}
+			}
+			return false
+		})
+	}
+
+	return f
+}
+
+// add appends a rule to the chain
+func (f *RepositoryFilter) add(name string, exclude func(repo *github.Repository) bool) {
+	f.rules = append(f.rules, repositoryRule{name: name, exclude: exclude})
+}
+
+// Apply returns the repositories no rule excludes and logs which rule
+// excluded the others. Each repository is attributed to the first rule that
+// matches it.
+func (f *RepositoryFilter) Apply(repos []*github.Repository) []*github.Repository {
+	excluded := make([][]string, len(f.rules))
+	var kept []*github.Repository
+
+	for _, repo := range repos {
+		keep := true
+		for i, rule := range f.rules {
+			if rule.exclude(repo) {
+				excluded[i] = append(excluded[i], repositoryFullName(repo))
+				keep = false
+				break
+			}
+		}
+		if keep {
+			kept = append(kept, repo)
+		}
+	}
+
+	for i, rule := range f.rules {
+		if len(excluded[i]) > 0 {
+			log.Printf("Excluded %d repositories (%s): %s\n", len(excluded[i]), rule.name, strings.Join(excluded[i], ", "))
+		}
+	}
+
+	return kept
+}
+
+// matchesAnyPattern reports whether the repository matches one of the globs
+func matchesAnyPattern(repo *github.Repository, patterns []string) bool {
+	name := strings.ToLower(repo.GetName())
+	fullName := strings.ToLower(repositoryFullName(repo))
+
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		target := name
+		if strings.Contains(pattern, "/") {
+			target = fullName
+		}
+		if matched, _ := path.Match(pattern, target); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// repositoryFullName returns owner/name, also for listings without full_name
+func repositoryFullName(repo *github.Repository) string {
+	if repo.GetFullName() != "" {
+		return repo.GetFullName()
+	}
+	return repo.GetOwner().GetLogin() + "/" + repo.GetName()
+}
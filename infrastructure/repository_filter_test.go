@@ -0,0 +1,91 @@
+package infrastructure
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v38/github"
+)
+
+func testRepositories() []*github.Repository {
+	repo := func(owner, name string) *github.Repository {
+		return &github.Repository{
+			Name:     github.String(name),
+			FullName: github.String(owner + "/" + name),
+			Owner:    &github.User{Login: github.String(owner)},
+		}
+	}
+
+	fork := repo("octocat", "linux")
+	fork.Fork = github.Bool(true)
+	archived := repo("octocat", "old-api")
+	archived.Archived = github.Bool(true)
+	template := repo("octocat", "starter")
+	template.IsTemplate = github.Bool(true)
+	private := repo("octocat", "secret")
+	private.Private = github.Bool(true)
+	dotfiles := repo("octocat", "config")
+	dotfiles.Topics = []string{"Dotfiles", "shell"}
+
+	return []*github.Repository{
+		repo("octocat", "api-server"),
+		repo("acme", "api-gateway"),
+		repo("octocat", "web"),
+		fork, archived, template, private, dotfiles,
+	}
+}
+
+func filteredNames(repos []*github.Repository) string {
+	var names []string
+	for _, repo := range repos {
+		names = append(names, repo.GetFullName())
+	}
+	return strings.Join(names, ",")
+}
+
+func TestRepositoryFilterForksOnly(t *testing.T) {
+	filter := NewRepositoryFilter(RepositoryFilterConfig{}, false)
+
+	got := filteredNames(filter.Apply(testRepositories()))
+	if strings.Contains(got, "linux") || !strings.Contains(got, "old-api") {
+		t.Errorf("Expected only the fork to be excluded, got: %s", got)
+	}
+
+	filter = NewRepositoryFilter(RepositoryFilterConfig{}, true)
+	if n := len(filter.Apply(testRepositories())); n != 8 {
+		t.Errorf("Expected every repository with forks included, got: %d", n)
+	}
+}
+
+func TestRepositoryFilterChain(t *testing.T) {
+	filter := NewRepositoryFilter(RepositoryFilterConfig{
+		ExcludeArchived:  true,
+		ExcludeTemplates: true,
+		ExcludePrivate:   true,
+		ExcludeTopics:    []string{"dotfiles"},
+		ExcludeRepos:     []string{"acme/*"},
+	}, false)
+
+	if got := filteredNames(filter.Apply(testRepositories())); got != "octocat/api-server,octocat/web" {
+		t.Errorf("Expected api-server and web to remain, got: %s", got)
+	}
+}
+
+func TestRepositoryFilterIncludePatterns(t *testing.T) {
+	filter := NewRepositoryFilter(RepositoryFilterConfig{
+		IncludeRepos: []string{"API-*", "octocat/web"},
+	}, true)
+
+	if got := filteredNames(filter.Apply(testRepositories())); got != "octocat/api-server,acme/api-gateway,octocat/web" {
+		t.Errorf("Expected the repositories matching the patterns, got: %s", got)
+	}
+}
+
+func TestRepositoryFilterConfigValidate(t *testing.T) {
+	if err := (RepositoryFilterConfig{ExcludeRepos: []string{"api-["}}).Validate(); err == nil {
+		t.Error("Expected an error for a malformed pattern")
+	}
+	if err := (RepositoryFilterConfig{IncludeRepos: []string{"*/api-?"}}).Validate(); err != nil {
+		t.Errorf("Expected a valid pattern, got: %v", err)
+	}
+}
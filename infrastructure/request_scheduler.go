@@ -0,0 +1,284 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxConcurrentRequests = 8
+	defaultMaxRetries            = 5
+	defaultRetryBaseDelay        = time.Second
+	defaultRetryMaxDelay         = 2 * time.Minute
+)
+
+// RequestScheduler is an http.RoundTripper that every GitHub call goes
+// through. It caps the number of requests in flight, retries 403/429/5xx
+// responses with jittered exponential backoff (honouring Retry-After), and
+// when X-RateLimit-Remaining reaches zero it holds all later requests until
+// the X-RateLimit-Reset window has passed instead of failing.
+type RequestScheduler struct {
+	base       http.RoundTripper
+	slots      chan struct{}
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// NewRequestScheduler wraps base with at most maxConcurrent requests in flight
+func NewRequestScheduler(base http.RoundTripper, maxConcurrent int) *RequestScheduler {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultMaxConcurrentRequests
+	}
+
+	return &RequestScheduler{
+		base:       base,
+		slots:      make(chan struct{}, maxConcurrent),
+		maxRetries: defaultMaxRetries,
+		baseDelay:  defaultRetryBaseDelay,
+		maxDelay:   defaultRetryMaxDelay,
+	}
+}
+
+// githubAPIHost is the host of the github.com REST and GraphQL APIs
+const githubAPIHost = "api.github.com"
+
+// RequestSchedulers keeps one RequestScheduler per API host and token, so
+// every client calling the same API with the same token shares one
+// concurrency limit and one view of the rate limit
+type RequestSchedulers struct {
+	maxConcurrent int
+
+	mu         sync.Mutex
+	schedulers map[string]*RequestScheduler
+}
+
+// NewRequestSchedulers creates schedulers allowing maxConcurrent requests in
+// flight per host and token
+func NewRequestSchedulers(maxConcurrent int) *RequestSchedulers {
+	return &RequestSchedulers{
+		maxConcurrent: maxConcurrent,
+		schedulers:    make(map[string]*RequestScheduler),
+	}
+}
+
+// scheduler returns the authenticated scheduler for host and token, creating
+// it on first use
+func (p *RequestSchedulers) scheduler(host, token string) *RequestScheduler {
+	sum := sha256.Sum256([]byte(token))
+	key := host + "/" + hex.EncodeToString(sum[:])
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s, ok := p.schedulers[key]
+	if !ok {
+		s = newGitHubTransport(token, p.maxConcurrent)
+		p.schedulers[key] = s
+	}
+	return s
+}
+
+// hostOf returns the host of rawURL, or rawURL itself when it has none
+func hostOf(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		return strings.ToLower(u.Host)
+	}
+	return rawURL
+}
+
+// RoundTrip implements http.RoundTripper
+func (s *RequestScheduler) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	// Wait out an exhausted rate limit before taking a slot
+	if err := s.waitForReset(ctx); err != nil {
+		return nil, err
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-s.slots }()
+
+	for attempt := 0; ; attempt++ {
+		if err := s.waitForReset(ctx); err != nil {
+			return nil, err
+		}
+
+		attemptReq, err := s.prepareAttempt(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := s.base.RoundTrip(attemptReq)
+		retryable := attempt < s.maxRetries && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+		if err != nil {
+			if !retryable || ctx.Err() != nil {
+				return nil, err
+			}
+			if err := s.sleep(ctx, s.backoff(attempt)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		delay, retry := s.retryDelay(resp, attempt)
+		if !retry || !retryable {
+			// The response already has its data; only the requests after it
+			// wait for the rate limit to reset
+			if resp.Header.Get("X-RateLimit-Remaining") == "0" && resp.StatusCode < 400 {
+				s.pauseUntil(rateLimitReset(resp))
+			}
+			return resp, nil
+		}
+
+		log.Printf("GitHub responded %s to %s, retrying in %s (attempt %d/%d)\n",
+			resp.Status, req.URL.Path, delay.Round(time.Second), attempt+1, s.maxRetries)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if err := s.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// prepareAttempt returns the request to send for the given attempt, with a
+// fresh body for retries
+func (s *RequestScheduler) prepareAttempt(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 {
+		return req, nil
+	}
+
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
+
+// retryDelay decides whether a response should be retried and after how long
+func (s *RequestScheduler) retryDelay(resp *http.Response, attempt int) (time.Duration, bool) {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+	case resp.StatusCode >= 500:
+		return s.backoff(attempt), true
+	case resp.StatusCode == http.StatusForbidden:
+		if !isRateLimited(resp) {
+			// A plain 403 is a permission problem, retrying won't help
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		reset := rateLimitReset(resp)
+		s.pauseUntil(reset)
+		return 0, true
+	}
+
+	return s.backoff(attempt), true
+}
+
+// isRateLimited reports whether a 403 response is a primary or secondary
+// rate limit rather than a permission error. The body is restored after
+// being inspected.
+func isRateLimited(resp *http.Response) bool {
+	if resp.Header.Get("Retry-After") != "" || resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return true
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(string(body)), "rate limit")
+}
+
+// rateLimitReset parses X-RateLimit-Reset, defaulting to one minute from now
+func rateLimitReset(resp *http.Response) time.Time {
+	if seconds, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		return time.Unix(seconds, 0)
+	}
+	return time.Now().Add(time.Minute)
+}
+
+// backoff returns an exponential delay with full jitter for the given attempt
+func (s *RequestScheduler) backoff(attempt int) time.Duration {
+	delay := s.baseDelay << attempt
+	if delay <= 0 || delay > s.maxDelay {
+		delay = s.maxDelay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// pauseUntil holds every request until the given time
+func (s *RequestScheduler) pauseUntil(until time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if until.After(s.pausedUntil) {
+		if wait := time.Until(until); wait > 0 {
+			log.Printf("GitHub rate limit exhausted, waiting %s for the reset window\n", wait.Round(time.Second))
+		}
+		s.pausedUntil = until
+	}
+}
+
+// waitForReset blocks while the scheduler is paused by a rate limit
+func (s *RequestScheduler) waitForReset(ctx context.Context) error {
+	s.mu.Lock()
+	wait := time.Until(s.pausedUntil)
+	s.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	return s.sleep(ctx, wait)
+}
+
+// sleep waits for d or until the context is cancelled
+func (s *RequestScheduler) sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
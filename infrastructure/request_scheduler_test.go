@@ -0,0 +1,218 @@
+package infrastructure
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestScheduler returns a scheduler with millisecond backoff
+func newTestScheduler(maxConcurrent int) *RequestScheduler {
+	s := NewRequestScheduler(nil, maxConcurrent)
+	s.baseDelay = time.Millisecond
+	s.maxDelay = 5 * time.Millisecond
+	return s
+}
+
+func TestRequestSchedulerRetriesRateLimits(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message": "You have exceeded a secondary rate limit."}`))
+		case 3:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newTestScheduler(2)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected request to succeed after retries, got: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got: %d", resp.StatusCode)
+	}
+	if calls != 4 {
+		t.Errorf("Expected 4 attempts, got: %d", calls)
+	}
+}
+
+func TestRequestSchedulerDoesNotRetryPermissionErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newTestScheduler(2)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected response, got: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusForbidden || !strings.Contains(string(body), "not accessible") {
+		t.Errorf("Expected the original 403 response, got: %d %s", resp.StatusCode, body)
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single attempt, got: %d", calls)
+	}
+}
+
+func TestRequestSchedulerGivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	scheduler := newTestScheduler(2)
+	resp, err := (&http.Client{Transport: scheduler}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected final response, got: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got: %d", resp.StatusCode)
+	}
+	if int(calls) != scheduler.maxRetries+1 {
+		t.Errorf("Expected %d attempts, got: %d", scheduler.maxRetries+1, calls)
+	}
+}
+
+func TestRequestSchedulerReplaysBody(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"query": "viewer"}` {
+			t.Errorf("Expected body to be replayed, got: %q", body)
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newTestScheduler(2)}
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"query": "viewer"}`))
+	if err != nil {
+		t.Fatalf("Expected request to succeed, got: %v", err)
+	}
+	resp.Body.Close()
+
+	if calls != 2 {
+		t.Errorf("Expected 2 attempts, got: %d", calls)
+	}
+}
+
+func TestRequestSchedulerCapsConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newTestScheduler(3)}
+	var wg sync.WaitGroup
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if resp, err := client.Get(server.URL); err == nil {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 3 {
+		t.Errorf("Expected at most 3 requests in flight, got: %d", maxInFlight)
+	}
+}
+
+func TestRequestSchedulerPausesWhenBudgetExhausted(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	}))
+	defer server.Close()
+
+	scheduler := newTestScheduler(2)
+
+	// The response that exhausts the budget is returned right away
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := scheduler.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Expected the exhausting response to be returned, got: %v", err)
+	}
+	resp.Body.Close()
+
+	// The next request waits for the reset window
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if _, err := scheduler.RoundTrip(req); err == nil {
+		t.Fatal("Expected the scheduler to wait for the reset window until the context expired")
+	}
+
+	if scheduler.pausedUntil.Unix() != reset.Unix() {
+		t.Errorf("Expected requests to be paused until %s, got: %s", reset, scheduler.pausedUntil)
+	}
+}
+
+func TestRequestSchedulersSharePerHostAndToken(t *testing.T) {
+	schedulers := NewRequestSchedulers(4)
+
+	rest := NewGitHubClient("token", false, WithRequestSchedulers(schedulers))
+	graphql := NewGitHubGraphQLClient("", "token", false, WithRequestSchedulers(schedulers))
+	other := NewGitHubGraphQLClient("", "other-token", false, WithRequestSchedulers(schedulers))
+	enterprise, err := NewGitHubEnterpriseClient("https://github.example.com", "token", false, WithRequestSchedulers(schedulers))
+	if err != nil {
+		t.Fatalf("Failed to create enterprise client: %v", err)
+	}
+
+	shared := schedulers.scheduler(githubAPIHost, "token")
+	if cap(shared.slots) != 4 {
+		t.Errorf("Expected 4 slots, got: %d", cap(shared.slots))
+	}
+	if graphql.httpClient.Transport != shared || rest.client.Client().Transport != shared {
+		t.Error("Expected the REST and GraphQL clients to share the scheduler of their token")
+	}
+	if other.httpClient.Transport == shared {
+		t.Error("Expected another token to get its own scheduler")
+	}
+	if enterprise.client.Client().Transport == shared {
+		t.Error("Expected another host to get its own scheduler")
+	}
+}
@@ -0,0 +1,62 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// restClient is a minimal JSON-over-HTTP helper shared by the GitLab and Gitea clients
+type restClient struct {
+	baseURL    string
+	httpClient *http.Client
+	authorize  func(req *http.Request)
+}
+
+// newRestClient creates a helper for the API rooted at baseURL
+func newRestClient(baseURL string, authorize func(req *http.Request)) *restClient {
+	return &restClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		authorize:  authorize,
+	}
+}
+
+// getJSON performs a GET request and decodes the JSON body into v. The
+// response is returned so callers can read pagination headers.
+func (c *restClient) getJSON(ctx context.Context, path string, query url.Values, v interface{}) (*http.Response, error) {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.authorize != nil {
+		c.authorize(req)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GET %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return resp, fmt.Errorf("GET %s: unexpected status %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return resp, fmt.Errorf("GET %s: failed to decode response: %w", path, err)
+	}
+
+	return resp, nil
+}
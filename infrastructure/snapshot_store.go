@@ -0,0 +1,155 @@
+package infrastructure
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"GitInsights/domain"
+)
+
+// snapshotVersion is bumped whenever the stored format changes; snapshots of
+// other versions are skipped when loading
+const snapshotVersion = 1
+
+// snapshotRecord is one line of the snapshot history
+type snapshotRecord struct {
+	Version       int                `json:"version"`
+	TakenAt       time.Time          `json:"taken_at"`
+	Username      string             `json:"username"`
+	TotalCommits  int                `json:"total_commits"`
+	TotalBytes    int                `json:"total_bytes"`
+	CurrentStreak int                `json:"current_streak"`
+	LongestStreak int                `json:"longest_streak"`
+	Languages     []snapshotLanguage `json:"languages"`
+	Precision     int                `json:"precision"`
+	// The settings the figures depend on; snapshots that predate them leave
+	// them empty
+	Since     time.Time `json:"since"`
+	Until     time.Time `json:"until"`
+	Window    string    `json:"window,omitempty"`
+	Weighting string    `json:"weighting,omitempty"`
+}
+
+// snapshotLanguage is a language's size and share in a snapshot
+type snapshotLanguage struct {
+	Language          string  `json:"language"`
+	Bytes             int     `json:"bytes"`
+	Percentage        float64 `json:"percentage"`
+	DisplayPercentage float64 `json:"display_percentage"`
+}
+
+// SaveSnapshot appends the headline figures of stats to the history file
+func (f *FileManager) SaveSnapshot(stats *domain.ProfileStats) (err error) {
+	if f.snapshotPath == "" {
+		return fmt.Errorf("no snapshot file configured")
+	}
+
+	record := snapshotRecord{
+		Version:       snapshotVersion,
+		TakenAt:       stats.LastUpdated,
+		Username:      stats.Username,
+		TotalCommits:  stats.TotalCommits,
+		TotalBytes:    stats.TotalBytes,
+		CurrentStreak: stats.CurrentStreak,
+		LongestStreak: stats.LongestStreak,
+		Precision:     stats.PercentagePrecision,
+		Since:         stats.Window.Since,
+		Until:         stats.Window.Until,
+		Window:        stats.Window.Spec,
+		Weighting:     stats.Weighting,
+	}
+	for _, lang := range stats.Languages {
+		record.Languages = append(record.Languages, snapshotLanguage{
+			Language:          lang.Language,
+			Bytes:             lang.Bytes,
+			Percentage:        lang.Percentage,
+			DisplayPercentage: lang.DisplayPercentage,
+		})
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.snapshotPath), 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	file, err := os.OpenFile(f.snapshotPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to close snapshot file: %w", cerr)
+		}
+	}()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadSnapshots reads every snapshot in the history file, oldest first. A
+// missing file is an empty history; unreadable lines are skipped.
+func (f *FileManager) LoadSnapshots() ([]domain.ProfileStats, error) {
+	if f.snapshotPath == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(f.snapshotPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+	defer file.Close()
+
+	var snapshots []domain.ProfileStats
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record snapshotRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil || record.Version != snapshotVersion {
+			continue
+		}
+
+		stats := domain.ProfileStats{
+			Username:            record.Username,
+			TotalBytes:          record.TotalBytes,
+			TotalCommits:        record.TotalCommits,
+			CurrentStreak:       record.CurrentStreak,
+			LongestStreak:       record.LongestStreak,
+			PercentagePrecision: record.Precision,
+			LastUpdated:         record.TakenAt,
+			Window:              domain.TimeWindow{Since: record.Since, Until: record.Until, Spec: record.Window},
+			Weighting:           record.Weighting,
+		}
+		for _, lang := range record.Languages {
+			// Snapshots that predate rounded shares show the exact ones
+			display := lang.DisplayPercentage
+			if display == 0 {
+				display = lang.Percentage
+			}
+			stats.Languages = append(stats.Languages, domain.LanguageStats{
+				Language:          lang.Language,
+				Bytes:             lang.Bytes,
+				Percentage:        lang.Percentage,
+				DisplayPercentage: display,
+			})
+		}
+		snapshots = append(snapshots, stats)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+
+	return snapshots, nil
+}
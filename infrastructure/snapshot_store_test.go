@@ -0,0 +1,70 @@
+package infrastructure
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"GitInsights/domain"
+)
+
+func TestFileManagerSnapshots(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history", "snapshots.jsonl")
+	fm := NewFileManager("README.md", WithSnapshotFile(path))
+
+	// A missing file is an empty history
+	snapshots, err := fm.LoadSnapshots()
+	if err != nil || len(snapshots) != 0 {
+		t.Fatalf("Expected an empty history, got: %v (%v)", snapshots, err)
+	}
+
+	first := &domain.ProfileStats{
+		Username:            "octocat",
+		TotalCommits:        100,
+		LongestStreak:       5,
+		Languages:           []domain.LanguageStats{{Language: "Go", Bytes: 900, Percentage: 90.04, DisplayPercentage: 90}},
+		PercentagePrecision: 1,
+		LastUpdated:         time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		Window:              domain.TimeWindow{Since: time.Date(2023, 10, 3, 12, 0, 0, 0, time.UTC), Spec: "90d.."},
+		Weighting:           "log-bytes",
+	}
+	second := &domain.ProfileStats{
+		Username:     "octocat",
+		TotalCommits: 140,
+		LastUpdated:  time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC),
+	}
+	for _, stats := range []*domain.ProfileStats{first, second} {
+		if err := fm.SaveSnapshot(stats); err != nil {
+			t.Fatalf("SaveSnapshot failed: %v", err)
+		}
+	}
+
+	// Lines from other versions or tools are skipped
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open snapshot file: %v", err)
+	}
+	file.WriteString("{\"version\":99}\nnot json\n")
+	file.Close()
+
+	snapshots, err = fm.LoadSnapshots()
+	if err != nil {
+		t.Fatalf("LoadSnapshots failed: %v", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("Expected 2 snapshots, got: %+v", snapshots)
+	}
+	got := snapshots[0]
+	if got.Username != "octocat" || got.TotalCommits != 100 || got.LongestStreak != 5 ||
+		!got.LastUpdated.Equal(first.LastUpdated) || len(got.Languages) != 1 || got.Languages[0].Percentage != 90.04 ||
+		got.Languages[0].DisplayPercentage != 90 || got.PercentagePrecision != 1 {
+		t.Errorf("Expected the first snapshot to round-trip, got: %+v", got)
+	}
+	if got.Window != first.Window || got.Weighting != "log-bytes" {
+		t.Errorf("Expected the settings to round-trip, got: %+v / %q", got.Window, got.Weighting)
+	}
+	if snapshots[1].TotalCommits != 140 || !snapshots[1].Window.IsZero() {
+		t.Errorf("Expected the second snapshot last, got: %+v", snapshots[1])
+	}
+}
@@ -0,0 +1,137 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"GitInsights/domain"
+)
+
+// SourceConfig describes one data source and the identity used on it
+type SourceConfig struct {
+	// Type is one of "github", "gitlab", "gitea" or "local"
+	Type string `json:"type"`
+	// BaseURL points to GitHub Enterprise, GitLab or Gitea instances
+	BaseURL string `json:"base_url,omitempty"`
+	// API selects the GitHub API flavour: "rest" (default) or "graphql"
+	API string `json:"api,omitempty"`
+	// TokenEnv names the environment variable holding the token; it
+	// defaults to GITHUB_TOKEN, GITLAB_TOKEN or GITEA_TOKEN by type
+	TokenEnv     string   `json:"token_env,omitempty"`
+	Repos        []string `json:"repos,omitempty"`
+	IncludeForks bool     `json:"include_forks,omitempty"`
+	AuthorEmails []string `json:"author_emails,omitempty"`
+	// Affiliation widens a GitHub REST source to repositories the token
+	// owner reaches as "owner", "collaborator" or "organization_member"
+	Affiliation []string `json:"affiliation,omitempty"`
+	// RepositoryFilterConfig narrows the repositories of GitHub REST sources
+	RepositoryFilterConfig
+	// User is the account given with --user, set on the primary source only.
+	// Gitea sources need no token for it, since public profiles are readable.
+	User string `json:"-"`
+}
+
+// LoadSourceConfigs reads a JSON file of the form {"sources": [...]}
+func LoadSourceConfigs(path string) ([]SourceConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sources config: %w", err)
+	}
+
+	var config struct {
+		Sources []SourceConfig `json:"sources"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse sources config: %w", err)
+	}
+
+	if len(config.Sources) == 0 {
+		return nil, fmt.Errorf("sources config %s defines no sources", path)
+	}
+
+	return config.Sources, nil
+}
+
+// NewSourceRepository creates the domain.GitHubRepository described by cfg.
+// githubOpts carry run-wide settings (scheduling, caching) for GitHub sources.
+func NewSourceRepository(cfg SourceConfig, githubOpts ...GitHubClientOption) (domain.GitHubRepository, error) {
+	token := func(defaultEnv string) (string, error) {
+		env := cfg.TokenEnv
+		if env == "" {
+			env = defaultEnv
+		}
+		value := os.Getenv(env)
+		if value == "" {
+			return "", fmt.Errorf("%s environment variable is not set", env)
+		}
+		return value, nil
+	}
+
+	switch cfg.Type {
+	case "github":
+		tok, err := token("GITHUB_TOKEN")
+		if err != nil {
+			return nil, err
+		}
+		if cfg.API == "graphql" {
+			if len(cfg.Affiliation) > 0 {
+				return nil, fmt.Errorf("affiliation is only supported by the GitHub REST API")
+			}
+			if !cfg.RepositoryFilterConfig.IsEmpty() {
+				return nil, fmt.Errorf("repository filters are only supported by the GitHub REST API")
+			}
+			return NewGitHubGraphQLClient(cfg.BaseURL, tok, cfg.IncludeForks, githubOpts...), nil
+		}
+		if cfg.API != "" && cfg.API != "rest" {
+			return nil, fmt.Errorf("unknown GitHub API %q (expected 'rest' or 'graphql')", cfg.API)
+		}
+		if err := cfg.RepositoryFilterConfig.Validate(); err != nil {
+			return nil, err
+		}
+		opts := append([]GitHubClientOption{
+			WithAuthorEmails(cfg.AuthorEmails),
+			WithRepositoryFilter(cfg.RepositoryFilterConfig),
+		}, githubOpts...)
+		if len(cfg.Affiliation) > 0 {
+			for _, affiliation := range cfg.Affiliation {
+				switch affiliation {
+				case "owner", "collaborator", "organization_member":
+				default:
+					return nil, fmt.Errorf("unknown affiliation %q (expected 'owner', 'collaborator' or 'organization_member')", affiliation)
+				}
+			}
+			opts = append(opts, WithAffiliation(cfg.Affiliation))
+		}
+		if cfg.BaseURL != "" {
+			return NewGitHubEnterpriseClient(cfg.BaseURL, tok, cfg.IncludeForks, opts...)
+		}
+		return NewGitHubClient(tok, cfg.IncludeForks, opts...), nil
+	case "gitlab":
+		tok, err := token("GITLAB_TOKEN")
+		if err != nil {
+			return nil, err
+		}
+		baseURL := cfg.BaseURL
+		if baseURL == "" {
+			baseURL = "https://gitlab.com"
+		}
+		return NewGitLabClient(baseURL, tok, cfg.IncludeForks, cfg.AuthorEmails), nil
+	case "gitea":
+		tok, err := token("GITEA_TOKEN")
+		if err != nil && cfg.User == "" {
+			return nil, err
+		}
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("base_url is required for gitea sources")
+		}
+		return NewGiteaClient(cfg.BaseURL, tok, cfg.IncludeForks, cfg.AuthorEmails), nil
+	case "local":
+		if len(cfg.Repos) == 0 {
+			return nil, fmt.Errorf("repos is required for local sources")
+		}
+		return NewLocalGitClient(cfg.Repos, cfg.AuthorEmails), nil
+	default:
+		return nil, fmt.Errorf("unknown source type %q (expected 'github', 'gitlab', 'gitea' or 'local')", cfg.Type)
+	}
+}
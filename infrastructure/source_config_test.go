@@ -0,0 +1,23 @@
+package infrastructure
+
+import "testing"
+
+func TestNewSourceRepositoryGiteaToken(t *testing.T) {
+	t.Setenv("GITEA_TOKEN", "")
+	cfg := SourceConfig{Type: "gitea", BaseURL: "https://codeberg.org"}
+
+	// The token owner can't be looked up without a token
+	if _, err := NewSourceRepository(cfg); err == nil {
+		t.Error("Expected an error without a token or user")
+	}
+
+	// A given user's public profile can
+	cfg.User = "forgejo-fan"
+	source, err := NewSourceRepository(cfg)
+	if err != nil {
+		t.Fatalf("Expected no token to be needed for a given user, got: %v", err)
+	}
+	if _, ok := source.(*GiteaClient); !ok {
+		t.Errorf("Expected a Gitea client, got: %T", source)
+	}
+}
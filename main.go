@@ -3,52 +3,292 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"GitInsights/domain"
 	"GitInsights/infrastructure"
 	"GitInsights/presentation"
 	"GitInsights/usecase"
 )
 
 func main() {
+	// An optional command comes before the flags
+	command := ""
+	args := os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+	if command != "" && command != "year-review" && command != "history" {
+		log.Fatalf("Unknown command %q (expected 'year-review' or 'history')", command)
+	}
+
 	// Parse command-line flags
 	includeForks := flag.Bool("include-forks", false, "Include forked repositories in analysis")
 	maxVisibleLanguages := flag.Int("max-visible-language", 10, "Maximum number of languages to display (rest grouped as 'Other')")
 	showCredit := flag.Bool("show-credit", true, "Show GitInsight credit in the generated output")
 	excludeLanguages := flag.String("exclude-languages", "", "Comma-separated list of languages to exclude (e.g., 'scss,html')")
-	flag.Parse()
+	since := flag.String("since", "", "Only analyze activity from this point on: a date (2006-01-02), a duration like '365d', '6m' or '2y', or 'this-year', 'last-year', 'this-month', 'last-month'")
+	until := flag.String("until", "", "Only analyze activity before the end of this point, in the same forms as --since")
+	timezone := flag.String("timezone", "auto", "IANA timezone used for time-based metrics (e.g., 'Europe/Berlin'), or 'auto' to use each commit's own offset")
+	authorEmails := flag.String("author-emails", "", "Comma-separated list of additional email addresses whose commits are yours")
+	source := flag.String("source", "github", "Data source to analyze: 'github', 'gitlab', 'gitea' or 'local'")
+	repos := flag.String("repos", "", "Comma-separated list of local repository paths (used with --source=local)")
+	baseURL := flag.String("base-url", "", "Base URL of a GitHub Enterprise, GitLab or Gitea/Forgejo instance (e.g., 'https://codeberg.org')")
+	githubAPI := flag.String("github-api", "rest", "GitHub API to use: 'rest' or 'graphql' (daily commit contributions, far fewer requests)")
+	maxConcurrentRequests := flag.Int("max-concurrent-requests", 8, "Maximum number of GitHub API requests in flight at once")
+	noCache := flag.Bool("no-cache", false, "Disable the on-disk cache of GitHub API responses")
+	cacheDir := flag.String("cache-dir", defaultCacheDir("http"), "Directory for cached GitHub API responses")
+	cacheTTL := flag.Duration("cache-ttl", time.Hour, "How long cached responses are used before being revalidated")
+	stateDir := flag.String("state-dir", defaultCacheDir("state"), "Directory where fetched commits are kept between runs")
+	fullRefresh := flag.Bool("full-refresh", false, "Ignore commits stored by previous runs and fetch the full history again")
+	user := flag.String("user", "", "Login of the user to analyze (defaults to the owner of the token); only public data is used for other users")
+	affiliation := flag.String("affiliation", "", "Comma-separated repository affiliations to analyze on GitHub: 'owner', 'collaborator', 'organization_member' (default: owned repositories only)")
+	includeRepos := flag.String("include-repos", "", "Comma-separated glob patterns of GitHub repositories to analyze (e.g., 'api-*,acme/web')")
+	excludeRepos := flag.String("exclude-repos", "", "Comma-separated glob patterns of GitHub repositories to skip")
+	excludeArchived := flag.Bool("exclude-archived", false, "Skip archived GitHub repositories")
+	excludeTemplates := flag.Bool("exclude-templates", false, "Skip GitHub template repositories")
+	excludePrivate := flag.Bool("exclude-private", false, "Skip private GitHub repositories")
+	excludeTopic := flag.String("exclude-topic", "", "Comma-separated GitHub topics whose repositories are skipped (e.g., 'dotfiles')")
+	deepLanguages := flag.Bool("deep-languages", false, "Recompute GitHub language totals from each repository's files, honouring linguist overrides in .gitattributes")
+	languageRules := flag.String("language-rules", "", "JSON file with language groups, renames and pinned languages")
+	percentagePrecision := flag.Int("percentage-precision", usecase.DefaultPercentagePrecision, "Number of decimals of the language percentages (0-4)")
+	languageWeighting := flag.String("language-weighting", "bytes", "How languages are weighted for percentages: "+strings.Join(usecase.LanguageWeightingNames, ", "))
+	org := flag.String("org", "", "Generate aggregate insights for a GitHub organization instead of a user")
+	team := flag.String("team", "", "Limit --org to the members and repositories of a team (team slug)")
+	sourcesConfig := flag.String("sources-config", "", "JSON file listing several sources to merge (overrides --source)")
+	year := flag.Int("year", time.Now().Year()-1, "Year summarized by the year-review command")
+	historyFile := flag.String("history-file", filepath.Join(defaultCacheDir("history"), "snapshots.jsonl"), "JSON-lines file where a snapshot of every run is kept")
+	noHistory := flag.Bool("no-history", false, "Neither store this run's snapshot nor show changes since earlier runs")
+	trendSince := flag.String("trend-since", "last-run", "Snapshot changes are measured against: 'last-run' or 'last-month'")
+	output := flag.String("output", "README.md", "File whose marker section is replaced with the generated content")
+	calendarSVG := flag.String("calendar-svg", "", "Also write the contribution calendar to this SVG file and show it instead of the text heatmap")
+	punchCardSVG := flag.String("punch-card-svg", "", "Also write the weekday by hour punch card to this SVG file and show it instead of the text grid")
+	if err := flag.CommandLine.Parse(args); err != nil {
+		log.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	if *team != "" && *org == "" {
+		log.Fatalf("--team requires --org")
+	}
+	if *org != "" && *user != "" {
+		log.Fatalf("--org and --user cannot be combined")
+	}
+	if command == "year-review" && (*org != "" || *since != "" || *until != "") {
+		log.Fatalf("year-review covers one --year of a user and cannot be combined with --org, --since or --until")
+	}
+
+	fileManager := infrastructure.NewFileManager(*output, infrastructure.WithSnapshotFile(*historyFile))
+	var markdownOpts []presentation.MarkdownOption
+	if *calendarSVG != "" {
+		markdownOpts = append(markdownOpts, presentation.WithCalendarSVG(imageSource(*output, *calendarSVG)))
+	}
+	if *punchCardSVG != "" {
+		markdownOpts = append(markdownOpts, presentation.WithPunchCardSVG(imageSource(*output, *punchCardSVG)))
+	}
+	markdownGen := presentation.NewMarkdownGenerator(*showCredit, markdownOpts...)
 
-	// Get GitHub token from environment
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		log.Fatal("GITHUB_TOKEN environment variable is not set")
+	// Listing the history needs no data source
+	if command == "history" {
+		snapshots, err := usecase.NewHistoryUseCase(fileManager, *user).GetHistory()
+		if err != nil {
+			log.Fatalf("Failed to read history: %v", err)
+		}
+		fmt.Print(markdownGen.GenerateHistory(snapshots))
+		return
 	}
 
+	// Resolve the timezone for time-bucketed metrics
+	var ucOpts []usecase.Option
+	location := time.Local
+	if *timezone != "auto" {
+		var err error
+		location, err = time.LoadLocation(*timezone)
+		if err != nil {
+			log.Fatalf("Invalid timezone %q: %v", *timezone, err)
+		}
+		ucOpts = append(ucOpts, usecase.WithLocation(location))
+	}
+	window, err := usecase.ParseTimeWindow(*since, *until, time.Now().In(location))
+	if err != nil {
+		log.Fatalf("Invalid time window: %v", err)
+	}
+	ucOpts = append(ucOpts, usecase.WithTimeWindow(window))
+	if *user != "" {
+		ucOpts = append(ucOpts, usecase.WithUsername(*user))
+	}
+	if *languageRules != "" {
+		rules, err := infrastructure.LoadLanguageRules(*languageRules)
+		if err != nil {
+			log.Fatalf("Failed to load language rules: %v", err)
+		}
+		ucOpts = append(ucOpts, usecase.WithLanguageRules(rules))
+	}
+	weighting, err := usecase.ParseLanguageWeighting(*languageWeighting)
+	if err != nil {
+		log.Fatalf("Invalid --language-weighting: %v", err)
+	}
+	ucOpts = append(ucOpts, usecase.WithLanguageWeighting(weighting))
+	if *percentagePrecision < 0 || *percentagePrecision > 4 {
+		log.Fatalf("--percentage-precision must be between 0 and 4")
+	}
+	ucOpts = append(ucOpts, usecase.WithPercentagePrecision(*percentagePrecision))
+
 	// Initialize dependencies
 	ctx := context.Background()
-	githubClient := infrastructure.NewGitHubClient(token, *includeForks)
-	fileManager := infrastructure.NewFileManager("README.md")
+	sourceConfigs := []infrastructure.SourceConfig{{
+		Type:         *source,
+		BaseURL:      *baseURL,
+		API:          *githubAPI,
+		Repos:        splitList(*repos),
+		IncludeForks: *includeForks,
+		AuthorEmails: splitList(*authorEmails),
+		Affiliation:  splitList(*affiliation),
+		RepositoryFilterConfig: infrastructure.RepositoryFilterConfig{
+			IncludeRepos:     splitList(*includeRepos),
+			ExcludeRepos:     splitList(*excludeRepos),
+			ExcludeArchived:  *excludeArchived,
+			ExcludeTemplates: *excludeTemplates,
+			ExcludePrivate:   *excludePrivate,
+			ExcludeTopics:    splitList(*excludeTopic),
+		},
+	}}
+	if *sourcesConfig != "" {
+		var err error
+		sourceConfigs, err = infrastructure.LoadSourceConfigs(*sourcesConfig)
+		if err != nil {
+			log.Fatalf("Failed to load sources: %v", err)
+		}
+	}
+	sourceConfigs[0].User = *user
+
+	// GitHub sources sharing a host and token share one request scheduler
+	githubOpts := []infrastructure.GitHubClientOption{
+		infrastructure.WithRequestSchedulers(infrastructure.NewRequestSchedulers(*maxConcurrentRequests)),
+		infrastructure.WithCommitState(infrastructure.NewCommitStateStore(*stateDir), *fullRefresh),
+	}
+	if *deepLanguages {
+		githubOpts = append(githubOpts, infrastructure.WithDeepLanguages())
+	}
+	if !*noCache {
+		githubOpts = append(githubOpts, infrastructure.WithCache(*cacheDir, *cacheTTL))
+	}
 
-	// Initialize use case
-	profileUseCase := usecase.NewProfileStatsUseCase(githubClient, *maxVisibleLanguages, *excludeLanguages)
+	var sources []domain.GitHubRepository
+	for _, cfg := range sourceConfigs {
+		source, err := infrastructure.NewSourceRepository(cfg, githubOpts...)
+		if err != nil {
+			log.Fatalf("Failed to initialize %s source: %v", cfg.Type, err)
+		}
+		sources = append(sources, source)
+	}
 
-	// Initialize presentation layer
-	markdownGen := presentation.NewMarkdownGenerator(*showCredit)
+	githubRepo := sources[0]
+	if len(sources) > 1 {
+		githubRepo = infrastructure.NewMultiSourceRepository(sources...)
+	}
+	// Execute business logic and generate output
+	var markdown string
+	var profile *domain.ProfileStats
+	var profileUseCase *usecase.ProfileStatsUseCase
+	section := presentation.ProfileSection
+	if command == "year-review" {
+		yearReviewUseCase := usecase.NewYearReviewUseCase(githubRepo, *year, *excludeLanguages, ucOpts...)
+		review, err := yearReviewUseCase.GetYearReview(ctx)
+		if err != nil {
+			log.Fatalf("Failed to get year review: %v", err)
+		}
+		markdown = markdownGen.GenerateYearReview(review)
+		section = presentation.YearReviewSection
+	} else if *org != "" {
+		orgRepo, ok := githubRepo.(domain.OrganizationRepository)
+		if !ok {
+			log.Fatalf("--org is only supported with a single GitHub source using the REST API")
+		}
 
-	// Execute business logic
-	stats, err := profileUseCase.GetProfileStats(ctx)
-	if err != nil {
-		log.Fatalf("Failed to get profile stats: %v", err)
+		orgUseCase := usecase.NewOrganizationStatsUseCase(orgRepo, *org, *team, *maxVisibleLanguages, *excludeLanguages, ucOpts...)
+		stats, err := orgUseCase.GetOrganizationStats(ctx)
+		if err != nil {
+			log.Fatalf("Failed to get organization stats: %v", err)
+		}
+		markdown = markdownGen.GenerateOrganization(stats)
+		profile = stats.Summary
+	} else {
+		if !*noHistory {
+			baseline, err := usecase.ParseTrendBaseline(*trendSince)
+			if err != nil {
+				log.Fatalf("Invalid --trend-since: %v", err)
+			}
+			ucOpts = append(ucOpts, usecase.WithHistory(fileManager, baseline))
+		}
+
+		profileUseCase = usecase.NewProfileStatsUseCase(githubRepo, *maxVisibleLanguages, *excludeLanguages, ucOpts...)
+		stats, err := profileUseCase.GetProfileStats(ctx)
+		if err != nil {
+			log.Fatalf("Failed to get profile stats: %v", err)
+		}
+		markdown = markdownGen.Generate(stats)
+		profile = stats
+	}
+
+	// Write the images the markdown links to
+	if profile != nil && *calendarSVG != "" && profile.Calendar != nil {
+		if err := fileManager.WriteFile(*calendarSVG, markdownGen.GenerateCalendarSVG(profile.Calendar)); err != nil {
+			log.Fatalf("Failed to write %s: %v", *calendarSVG, err)
+		}
+		log.Printf("🗓️ Wrote contribution calendar to %s", *calendarSVG)
+	}
+	if profile != nil && *punchCardSVG != "" && profile.WorkingWindow != nil {
+		if err := fileManager.WriteFile(*punchCardSVG, markdownGen.GeneratePunchCardSVG(profile.PunchCard, profile.WorkingWindow)); err != nil {
+			log.Fatalf("Failed to write %s: %v", *punchCardSVG, err)
+		}
+		log.Printf("🕐 Wrote punch card to %s", *punchCardSVG)
+	}
+
+	// Update the output file
+	if err := fileManager.UpdateSection(section, markdown); err != nil {
+		log.Fatalf("Failed to update %s: %v", *output, err)
+	}
+
+	// Only runs whose output was written become a baseline for later trends
+	if profileUseCase != nil {
+		if err := profileUseCase.RecordSnapshot(profile); err != nil {
+			log.Fatalf("Failed to record history: %v", err)
+		}
 	}
 
-	// Generate output
-	markdown := markdownGen.Generate(stats)
+	log.Printf("✅ Successfully updated %s with Git Insights!\n", *output)
+}
 
-	// Update README
-	if err := fileManager.UpdateReadme(markdown); err != nil {
-		log.Fatalf("Failed to update README: %v", err)
+// imageSource returns the path of an image as linked from the output file
+func imageSource(output, image string) string {
+	src, err := filepath.Rel(filepath.Dir(output), image)
+	if err != nil {
+		src = image
 	}
+	return filepath.ToSlash(src)
+}
 
-	log.Println("✅ Successfully updated README.md with Git Insights!")
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			items = append(items, trimmed)
+		}
+	}
+	return items
+}
+
+// defaultCacheDir returns a GitInsights directory inside the user cache directory
+func defaultCacheDir(name string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "gitinsights", name)
 }
@@ -0,0 +1,128 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+
+	"GitInsights/domain"
+)
+
+// Number of periods shown by the commit trend charts
+const (
+	sparklineMonths = 24
+	barChartMonths  = 12
+)
+
+// sparklineChars are the sparkline heights from no commits to the most
+var sparklineChars = []rune("▁▂▃▄▅▆▇█")
+
+// commitTrendsSection renders the monthly and yearly commits as sparklines,
+// a summary of the latest month and bar charts
+func (m *MarkdownGenerator) commitTrendsSection(stats *domain.ProfileStats) []string {
+	var lines []string
+
+	monthly := lastPeriods(stats.MonthlyCommits, sparklineMonths)
+	yearly := stats.YearlyCommits
+
+	lines = append(lines, "<div align=\"center\">")
+	lines = append(lines, "")
+	lines = append(lines, "## 📆 Commit Trends")
+	lines = append(lines, "")
+	lines = append(lines, "</div>")
+	lines = append(lines, "")
+	lines = append(lines, "```text")
+	lines = append(lines, fmt.Sprintf("Monthly  %s  %s – %s", sparkline(monthly), monthly[0].Start.Format("Jan 2006"), monthly[len(monthly)-1].Start.Format("Jan 2006")))
+	lines = append(lines, fmt.Sprintf("Yearly   %s  %d – %d", sparkline(yearly), yearly[0].Start.Year(), yearly[len(yearly)-1].Start.Year()))
+	lines = append(lines, "```")
+	lines = append(lines, "")
+
+	lines = append(lines, "<div align=\"center\">")
+	lines = append(lines, "")
+	lines = append(lines, m.monthTrendLabel(stats.MonthlyCommits))
+	lines = append(lines, "")
+	lines = append(lines, "</div>")
+	lines = append(lines, "")
+
+	lines = append(lines, "<details>")
+	lines = append(lines, "<summary><b>📊 Commits per Month and Year</b></summary>")
+	lines = append(lines, "")
+	lines = append(lines, "```text")
+	lines = append(lines, m.periodBars(lastPeriods(stats.MonthlyCommits, barChartMonths), "Jan 2006")...)
+	lines = append(lines, "")
+	lines = append(lines, m.periodBars(yearly, "2006")...)
+	lines = append(lines, "```")
+	lines = append(lines, "")
+	lines = append(lines, "</details>")
+	lines = append(lines, "")
+
+	return lines
+}
+
+// monthTrendLabel compares the latest month with the one before and tells
+// whether the 3-month moving average is rising or falling
+func (m *MarkdownGenerator) monthTrendLabel(monthly []domain.PeriodCommits) string {
+	latest := monthly[len(monthly)-1]
+	parts := []string{fmt.Sprintf("<b>%s:</b> %s", latest.Start.Format("Jan 2006"), countOf(latest.Commits, "commit"))}
+
+	if len(monthly) > 1 {
+		previous := monthly[len(monthly)-2]
+		parts = append(parts, m.periodChange(latest.Commits, previous.Commits)+" vs "+previous.Start.Format("Jan"))
+
+		direction := "➖ steady"
+		switch {
+		case latest.MovingAverage > previous.MovingAverage:
+			direction = "📈 rising"
+		case latest.MovingAverage < previous.MovingAverage:
+			direction = "📉 falling"
+		}
+		parts = append(parts, fmt.Sprintf("3-month average %.1f, %s", latest.MovingAverage, direction))
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// periodBars draws a bar per period with its commits and moving average
+func (m *MarkdownGenerator) periodBars(series []domain.PeriodCommits, layout string) []string {
+	maxCommits := 0
+	for _, period := range series {
+		if period.Commits > maxCommits {
+			maxCommits = period.Commits
+		}
+	}
+
+	var lines []string
+	for _, period := range series {
+		bar := m.generateModernCommitBar(period.Commits, maxCommits)
+		lines = append(lines, fmt.Sprintf("%-8s %s %5d commits  avg %7.1f", period.Start.Format(layout), bar, period.Commits, period.MovingAverage))
+	}
+	return lines
+}
+
+// sparkline draws one character per period, scaled to the busiest one
+func sparkline(series []domain.PeriodCommits) string {
+	maxCommits := 0
+	for _, period := range series {
+		if period.Commits > maxCommits {
+			maxCommits = period.Commits
+		}
+	}
+
+	chars := make([]rune, len(series))
+	for i, period := range series {
+		level := 0
+		if period.Commits > 0 {
+			// Any commit lifts the line above the baseline
+			level = max(period.Commits*(len(sparklineChars)-1)/maxCommits, 1)
+		}
+		chars[i] = sparklineChars[level]
+	}
+	return string(chars)
+}
+
+// lastPeriods returns the latest n periods of series
+func lastPeriods(series []domain.PeriodCommits, n int) []domain.PeriodCommits {
+	if len(series) > n {
+		return series[len(series)-n:]
+	}
+	return series
+}
@@ -0,0 +1,163 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+
+	"GitInsights/domain"
+)
+
+// calendarLevelChars are the heatmap cells of levels 0-4
+var calendarLevelChars = []rune{'·', '░', '▒', '▓', '█'}
+
+// calendarLevelColors are GitHub's light theme colors of levels 0-4
+var calendarLevelColors = []string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"}
+
+// calendarDayLabels names the rows GitHub labels, Sunday first
+var calendarDayLabels = []string{"", "Mon", "", "Wed", "", "Fri", ""}
+
+// calendarSection renders the contribution calendar as a Unicode heatmap, or
+// as an image when an SVG path is configured
+func (m *MarkdownGenerator) calendarSection(calendar *domain.ContributionCalendar) []string {
+	var lines []string
+
+	lines = append(lines, "<div align=\"center\">")
+	lines = append(lines, "")
+	lines = append(lines, "## 🗓️ Contribution Calendar")
+	lines = append(lines, "")
+
+	if m.calendarSVGPath != "" {
+		lines = append(lines, fmt.Sprintf("<img src=\"%s\" alt=\"%s in the last year\"/>", m.calendarSVGPath, countOf(calendar.Total, "contribution")))
+		lines = append(lines, "")
+		lines = append(lines, "</div>")
+		lines = append(lines, "")
+		return lines
+	}
+
+	lines = append(lines, "</div>")
+	lines = append(lines, "")
+	lines = append(lines, "```text")
+
+	// Month names above the first week that starts in them
+	header := []rune(strings.Repeat(" ", 4+domain.CalendarWeeks))
+	for _, label := range calendarMonthLabels(calendar) {
+		copy(header[4+label.week:], []rune(label.name))
+	}
+	lines = append(lines, strings.TrimRight(string(header), " "))
+
+	for weekday := 0; weekday < 7; weekday++ {
+		row := []rune(fmt.Sprintf("%-4s", calendarDayLabels[weekday]))
+		for week := range calendar.Weeks {
+			day := calendar.Weeks[week][weekday]
+			if day.Date.IsZero() {
+				row = append(row, ' ')
+				continue
+			}
+			row = append(row, calendarLevelChars[day.Level])
+		}
+		lines = append(lines, strings.TrimRight(string(row), " "))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("%s in the last year    Less %s More", countOf(calendar.Total, "contribution"), m.calendarLegend()))
+	lines = append(lines, "```")
+	lines = append(lines, "")
+
+	return lines
+}
+
+// calendarLegend lists the heatmap cells from the lowest to the highest level
+func (m *MarkdownGenerator) calendarLegend() string {
+	cells := make([]string, len(calendarLevelChars))
+	for i, char := range calendarLevelChars {
+		cells[i] = string(char)
+	}
+	return strings.Join(cells, " ")
+}
+
+// calendarMonthLabel is a month name placed above a week column
+type calendarMonthLabel struct {
+	name string
+	week int
+}
+
+// calendarMonthLabels places each month above the first week whose Sunday is
+// in it, unless the name would run past the last column. The first column
+// only gets a label when the next one is far enough away not to overlap.
+func calendarMonthLabels(calendar *domain.ContributionCalendar) []calendarMonthLabel {
+	var labels []calendarMonthLabel
+	for week := 1; week < domain.CalendarWeeks; week++ {
+		sunday := calendar.Weeks[week][0].Date
+		if sunday.IsZero() {
+			break
+		}
+		if sunday.Month() != calendar.Weeks[week-1][0].Date.Month() && week+3 <= domain.CalendarWeeks {
+			labels = append(labels, calendarMonthLabel{name: sunday.Format("Jan"), week: week})
+		}
+	}
+
+	first := calendar.Weeks[0][0].Date
+	if !first.IsZero() && (len(labels) == 0 || labels[0].week >= 4) {
+		labels = append([]calendarMonthLabel{{name: first.Format("Jan"), week: 0}}, labels...)
+	}
+	return labels
+}
+
+// GenerateCalendarSVG renders the contribution calendar as a self-contained
+// SVG image in GitHub's colors, with a tooltip on every day
+func (m *MarkdownGenerator) GenerateCalendarSVG(calendar *domain.ContributionCalendar) string {
+	const (
+		cell    = 10
+		step    = 13
+		left    = 30
+		top     = 20
+		fontCSS = "font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Helvetica,Arial,sans-serif;font-size:9px;fill:#57606a"
+	)
+	width := left + domain.CalendarWeeks*step
+	height := top + 7*step + 24
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n", width, height, width, height)
+	fmt.Fprintf(&b, "<style>text{%s}</style>\n", fontCSS)
+
+	for _, label := range calendarMonthLabels(calendar) {
+		fmt.Fprintf(&b, "<text x=\"%d\" y=\"%d\">%s</text>\n", left+label.week*step, top-7, label.name)
+	}
+	for weekday, label := range calendarDayLabels {
+		if label != "" {
+			fmt.Fprintf(&b, "<text x=\"0\" y=\"%d\">%s</text>\n", top+weekday*step+cell-1, label)
+		}
+	}
+
+	for week := range calendar.Weeks {
+		for weekday, day := range calendar.Weeks[week] {
+			if day.Date.IsZero() {
+				continue
+			}
+			fmt.Fprintf(&b, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" rx=\"2\" fill=\"%s\"><title>%s on %s</title></rect>\n",
+				left+week*step, top+weekday*step, cell, cell, calendarLevelColors[day.Level],
+				countOf(day.Count, "contribution"), day.Date.Format("Monday, January 2, 2006"))
+		}
+	}
+
+	// Total and legend below the grid
+	legendY := top + 7*step + 8
+	fmt.Fprintf(&b, "<text x=\"%d\" y=\"%d\">%s in the last year</text>\n", left, legendY+cell-1, countOf(calendar.Total, "contribution"))
+	legendX := width - len(calendarLevelColors)*step - 30
+	fmt.Fprintf(&b, "<text x=\"%d\" y=\"%d\" text-anchor=\"end\">Less</text>\n", legendX-4, legendY+cell-1)
+	for level, color := range calendarLevelColors {
+		fmt.Fprintf(&b, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" rx=\"2\" fill=\"%s\"/>\n", legendX+level*step, legendY, cell, cell, color)
+	}
+	fmt.Fprintf(&b, "<text x=\"%d\" y=\"%d\">More</text>\n", legendX+len(calendarLevelColors)*step+1, legendY+cell-1)
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// countOf describes a number of things, such as "1 commit" or "2 commits"
+func countOf(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
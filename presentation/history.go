@@ -0,0 +1,31 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+
+	"GitInsights/domain"
+)
+
+// GenerateHistory lists stored snapshots as a markdown table, oldest first
+func (m *MarkdownGenerator) GenerateHistory(snapshots []domain.ProfileStats) string {
+	if len(snapshots) == 0 {
+		return "No snapshots recorded yet.\n"
+	}
+
+	var lines []string
+	lines = append(lines, "| Date | User | Commits | Current Streak | Longest Streak | Top Languages |")
+	lines = append(lines, "|:---|:---|---:|---:|---:|:---|")
+	for _, snapshot := range snapshots {
+		lines = append(lines, fmt.Sprintf("| %s | %s | %d | %d | %d | %s |",
+			snapshot.LastUpdated.Format("2006-01-02 15:04"),
+			snapshot.Username,
+			snapshot.TotalCommits,
+			snapshot.CurrentStreak,
+			snapshot.LongestStreak,
+			m.topLanguages(snapshot.Languages, 3, snapshot.PercentagePrecision),
+		))
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
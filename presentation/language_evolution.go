@@ -0,0 +1,98 @@
+package presentation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"GitInsights/domain"
+)
+
+// stackSquares is the number of squares of a year's stacked bar
+const stackSquares = 10
+
+// evolutionColors mark the named languages in order; "Other" is always white
+var evolutionColors = []string{"🟦", "🟩", "🟨", "🟧", "🟥", "🟪", "🟫"}
+
+// languageEvolutionSection renders the language mix of every year as a table
+// with a stacked bar per year
+func (m *MarkdownGenerator) languageEvolutionSection(stats *domain.ProfileStats) []string {
+	var lines []string
+
+	lines = append(lines, "<div align=\"center\">")
+	lines = append(lines, "")
+	lines = append(lines, "## 🧬 Language Evolution")
+	lines = append(lines, "")
+	lines = append(lines, "</div>")
+	lines = append(lines, "")
+
+	// Every year lists the same languages, so the first one names the columns
+	columns := stats.LanguageEvolution[0].Languages
+	header := []string{"Year"}
+	align := []string{":---:"}
+	for i, lang := range columns {
+		header = append(header, evolutionColor(i, lang.Language)+" "+lang.Language)
+		align = append(align, "---:")
+	}
+	header = append(header, "Mix", "Commits")
+	align = append(align, ":---", "---:")
+	lines = append(lines, "| "+strings.Join(header, " | ")+" |")
+	lines = append(lines, "|"+strings.Join(align, "|")+"|")
+
+	for _, year := range stats.LanguageEvolution {
+		row := []string{fmt.Sprint(year.Year)}
+		for _, lang := range year.Languages {
+			if lang.DisplayPercentage == 0 {
+				row = append(row, "—")
+				continue
+			}
+			row = append(row, fmt.Sprintf("%.*f%%", stats.PercentagePrecision, lang.DisplayPercentage))
+		}
+		row = append(row, stackedBar(year.Languages), fmt.Sprint(year.Commits))
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+	}
+	lines = append(lines, "")
+
+	return lines
+}
+
+// evolutionColor returns the square marking the language in the given column
+func evolutionColor(column int, language string) string {
+	if language == "Other" || column >= len(evolutionColors) {
+		return "⬜"
+	}
+	return evolutionColors[column]
+}
+
+// stackedBar splits a fixed number of squares between the languages by
+// their share, handing out rounded-off squares to the largest remainders
+func stackedBar(languages []domain.LanguageStats) string {
+	squares := make([]int, len(languages))
+	remainders := make([]float64, len(languages))
+	assigned := 0
+	for i, lang := range languages {
+		exact := lang.DisplayPercentage / 100 * stackSquares
+		squares[i] = int(math.Floor(exact))
+		remainders[i] = exact - float64(squares[i])
+		assigned += squares[i]
+	}
+
+	order := make([]int, len(languages))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+	for i := 0; assigned < stackSquares && i < len(order); i++ {
+		squares[order[i]]++
+		assigned++
+	}
+
+	var bar strings.Builder
+	for i, lang := range languages {
+		bar.WriteString(strings.Repeat(evolutionColor(i, lang.Language), squares[i]))
+	}
+	return bar.String()
+}
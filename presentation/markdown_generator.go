@@ -3,27 +3,64 @@ package presentation
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"GitInsights/domain"
 )
 
 // MarkdownGenerator generates markdown content for profile stats
 type MarkdownGenerator struct {
-	showCredit bool
+	showCredit       bool
+	calendarSVGPath  string
+	punchCardSVGPath string
+}
+
+// MarkdownOption configures optional behaviour of MarkdownGenerator
+type MarkdownOption func(*MarkdownGenerator)
+
+// WithCalendarSVG shows the contribution calendar as the SVG image at path,
+// relative to the generated file, instead of a text heatmap
+func WithCalendarSVG(path string) MarkdownOption {
+	return func(m *MarkdownGenerator) {
+		m.calendarSVGPath = path
+	}
+}
+
+// WithPunchCardSVG shows the punch card as the SVG image at path, relative to
+// the generated file, instead of a text grid
+func WithPunchCardSVG(path string) MarkdownOption {
+	return func(m *MarkdownGenerator) {
+		m.punchCardSVGPath = path
+	}
 }
 
 // NewMarkdownGenerator creates a new markdown generator
-func NewMarkdownGenerator(showCredit bool) *MarkdownGenerator {
-	return &MarkdownGenerator{
+func NewMarkdownGenerator(showCredit bool, opts ...MarkdownOption) *MarkdownGenerator {
+	m := &MarkdownGenerator{
 		showCredit: showCredit,
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 // Generate creates markdown content from profile stats
 func (m *MarkdownGenerator) Generate(stats *domain.ProfileStats) string {
+	return m.generate(stats, nil)
+}
+
+// GenerateOrganization creates markdown content from organization stats: the
+// aggregate profile followed by the contributors ranking
+func (m *MarkdownGenerator) GenerateOrganization(stats *domain.OrganizationStats) string {
+	return m.generate(stats.Summary, m.contributorsSection(stats))
+}
+
+// generate renders the profile sections, then the extra lines, then the footer
+func (m *MarkdownGenerator) generate(stats *domain.ProfileStats, extra []string) string {
 	var lines []string
 
-	lines = append(lines, "<!--START_SECTION:GitInsights-->")
+	lines = append(lines, "<!--START_SECTION:"+ProfileSection+"-->")
 	lines = append(lines, "")
 
 	// Modern Header with Gradient Effect
@@ -31,6 +68,10 @@ func (m *MarkdownGenerator) Generate(stats *domain.ProfileStats) string {
 	lines = append(lines, "")
 	lines = append(lines, "[![Profile Stats](https://img.shields.io/badge/Git-Insights-blueviolet?style=for-the-badge&logo=github)](https://github.com/awcodify/GitInsights)")
 	lines = append(lines, "")
+	if !stats.Window.IsZero() {
+		lines = append(lines, "<sub>📆 "+m.windowLabel(stats.Window)+"</sub>")
+		lines = append(lines, "")
+	}
 	lines = append(lines, "</div>")
 	lines = append(lines, "")
 	lines = append(lines, "---")
@@ -65,6 +106,10 @@ func (m *MarkdownGenerator) Generate(stats *domain.ProfileStats) string {
 	lines = append(lines, "</tr>")
 	lines = append(lines, "</table>")
 	lines = append(lines, "")
+	if stats.Trend != nil {
+		lines = append(lines, "<p align=\"center\"><sub>"+m.trendLabel(stats.Trend)+"</sub></p>")
+		lines = append(lines, "")
+	}
 
 	// Productivity Insights
 	lines = append(lines, "<div align=\"center\">")
@@ -119,6 +164,21 @@ func (m *MarkdownGenerator) Generate(stats *domain.ProfileStats) string {
 	lines = append(lines, "```")
 	lines = append(lines, "")
 
+	// Contribution Calendar
+	if stats.Calendar != nil {
+		lines = append(lines, m.calendarSection(stats.Calendar)...)
+	}
+
+	// Punch Card
+	if stats.WorkingWindow != nil {
+		lines = append(lines, m.punchCardSection(stats)...)
+	}
+
+	// Commit Trends
+	if len(stats.MonthlyCommits) > 0 {
+		lines = append(lines, m.commitTrendsSection(stats)...)
+	}
+
 	// Language Distribution
 	lines = append(lines, "<div align=\"center\">")
 	lines = append(lines, "")
@@ -137,7 +197,7 @@ func (m *MarkdownGenerator) Generate(stats *domain.ProfileStats) string {
 		if i >= 5 {
 			break
 		}
-		badges = append(badges, fmt.Sprintf("![%s](https://img.shields.io/badge/%s-%.1f%%25-blue?style=flat-square&logo=%s)", lang.Language, strings.ReplaceAll(lang.Language, " ", "_"), lang.Percentage, m.getLanguageLogo(lang.Language)))
+		badges = append(badges, fmt.Sprintf("![%s](https://img.shields.io/badge/%s-%.*f%%25-blue?style=flat-square&logo=%s)", lang.Language, strings.ReplaceAll(lang.Language, " ", "_"), stats.PercentagePrecision, lang.DisplayPercentage, m.getLanguageLogo(lang.Language)))
 	}
 	lines = append(lines, strings.Join(badges, " "))
 
@@ -159,12 +219,14 @@ func (m *MarkdownGenerator) Generate(stats *domain.ProfileStats) string {
 		progressBar := m.generateColoredProgressBar(lang.Percentage)
 		langEmoji := m.getLanguageEmoji(lang.Language)
 		lines = append(lines, fmt.Sprintf(
-			"%s %-*s %s %6.2f%%",
+			"%s %-*s %s %*.*f%%",
 			langEmoji,
 			maxLength,
 			lang.Language,
 			progressBar,
-			lang.Percentage,
+			stats.PercentagePrecision+4,
+			stats.PercentagePrecision,
+			lang.DisplayPercentage,
 		))
 	}
 
@@ -173,6 +235,13 @@ func (m *MarkdownGenerator) Generate(stats *domain.ProfileStats) string {
 	lines = append(lines, "</details>")
 	lines = append(lines, "")
 
+	// Language Evolution, once there is more than one year to compare
+	if len(stats.LanguageEvolution) > 1 {
+		lines = append(lines, m.languageEvolutionSection(stats)...)
+	}
+
+	lines = append(lines, extra...)
+
 	// Footer
 	lines = append(lines, "---")
 	lines = append(lines, "")
@@ -190,11 +259,136 @@ func (m *MarkdownGenerator) Generate(stats *domain.ProfileStats) string {
 	lines = append(lines, "")
 	lines = append(lines, "</div>")
 	lines = append(lines, "")
-	lines = append(lines, "<!--END_SECTION:GitInsights-->")
+	lines = append(lines, "<!--END_SECTION:"+ProfileSection+"-->")
 
 	return strings.Join(lines, "\n")
 }
 
+// contributorsSection ranks the members by commits, with the languages they
+// work in most
+func (m *MarkdownGenerator) contributorsSection(stats *domain.OrganizationStats) []string {
+	var lines []string
+
+	lines = append(lines, "<div align=\"center\">")
+	lines = append(lines, "")
+	lines = append(lines, "## 👥 Top Contributors")
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("<sub>%d members · %d repositories</sub>", len(stats.Members), stats.Repositories))
+	lines = append(lines, "")
+	lines = append(lines, "</div>")
+	lines = append(lines, "")
+
+	lines = append(lines, "| Rank | Member | Commits | Repositories | Top Languages | Last Commit |")
+	lines = append(lines, "|:---:|:---|---:|---:|:---|:---:|")
+
+	inactive := 0
+	for i, member := range stats.Members {
+		if member.Commits == 0 {
+			inactive++
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("| %s | [@%s](https://github.com/%s) | %d | %d | %s | %s |",
+			m.getRankBadge(i+1),
+			member.Login,
+			member.Login,
+			member.Commits,
+			member.Repositories,
+			m.topLanguages(member.Languages, 3, stats.Summary.PercentagePrecision),
+			member.LastCommit.Format("2006-01-02"),
+		))
+	}
+	lines = append(lines, "")
+
+	if inactive > 0 {
+		lines = append(lines, "<div align=\"center\">")
+		lines = append(lines, "")
+		lines = append(lines, fmt.Sprintf("<sub>%d members without commits in these repositories</sub>", inactive))
+		lines = append(lines, "")
+		lines = append(lines, "</div>")
+		lines = append(lines, "")
+	}
+
+	return lines
+}
+
+// trendLabel summarizes the changes since an earlier snapshot, with the
+// three largest language shifts
+func (m *MarkdownGenerator) trendLabel(trend *domain.Trend) string {
+	parts := []string{fmt.Sprintf("%+d commits", trend.Commits)}
+	if change := streakChange("current streak", trend.CurrentStreak); change != "" {
+		parts = append(parts, change)
+	}
+	if change := streakChange("longest streak", trend.LongestStreak); change != "" {
+		parts = append(parts, change)
+	}
+	for i, lang := range trend.Languages {
+		if i == 3 {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s %s %+.1f%%", m.getLanguageEmoji(lang.Language), lang.Language, lang.Change))
+	}
+
+	return "📊 Since " + trend.Since.Format("Jan 2, 2006") + ": " + strings.Join(parts, " · ")
+}
+
+// streakChange describes a change of a streak in words, such as "longest
+// streak dropped by 2 days", or returns "" when it didn't change
+func streakChange(streak string, days int) string {
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%s improved by %s", streak, countOf(days, "day"))
+	case days < 0:
+		return fmt.Sprintf("%s dropped by %s", streak, countOf(-days, "day"))
+	}
+	return ""
+}
+
+// windowLabel describes the period the statistics cover, with inclusive dates
+func (m *MarkdownGenerator) windowLabel(window domain.TimeWindow) string {
+	const layout = "Jan 2, 2006"
+	since := window.Since.Format(layout)
+	until := window.Until.Add(-time.Nanosecond).Format(layout)
+
+	switch {
+	case window.Since.IsZero():
+		return "Activity until " + until
+	case window.Until.IsZero():
+		return "Activity since " + since
+	default:
+		return "Activity from " + since + " to " + until
+	}
+}
+
+// getRankBadge returns a medal for the top three ranks and the number otherwise
+func (m *MarkdownGenerator) getRankBadge(rank int) string {
+	medals := map[int]string{1: "🥇", 2: "🥈", 3: "🥉"}
+	if medal, ok := medals[rank]; ok {
+		return medal
+	}
+	return fmt.Sprintf("%d", rank)
+}
+
+// topLanguages lists up to limit languages with their share shown with
+// precision decimals, skipping the "Other" group
+func (m *MarkdownGenerator) topLanguages(languages []domain.LanguageStats, limit, precision int) string {
+	var parts []string
+	for _, lang := range languages {
+		if len(parts) == limit {
+			break
+		}
+		if lang.Language == "Other" {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s %s %.*f%%", m.getLanguageEmoji(lang.Language), lang.Language, precision, lang.DisplayPercentage))
+	}
+
+	if len(parts) == 0 {
+		return "—"
+	}
+	return strings.Join(parts, ", ")
+}
+
 // generateProgressBar creates a visual progress bar
 func (m *MarkdownGenerator) generateProgressBar(percentage float64) string {
 	const barWidth = 40
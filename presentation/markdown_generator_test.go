@@ -14,14 +14,15 @@ func TestMarkdownGeneration(t *testing.T) {
 		Username:   "testuser",
 		TotalBytes: 1500,
 		Languages: []domain.LanguageStats{
-			{Language: "Go", Bytes: 1000, Percentage: 66.67},
-			{Language: "Java", Bytes: 500, Percentage: 33.33},
+			{Language: "Go", Bytes: 1000, Percentage: 66.666, DisplayPercentage: 66.67},
+			{Language: "Java", Bytes: 500, Percentage: 33.333, DisplayPercentage: 33.33},
 		},
-		MostProductiveDay:  "Monday",
-		MostProductiveHour: "10:00 - 11:00",
-		AccountAge:         "5 years 9 months",
-		CurrentStreak:      15,
-		LongestStreak:      45,
+		PercentagePrecision: 2,
+		MostProductiveDay:   "Monday",
+		MostProductiveHour:  "10:00 - 11:00",
+		AccountAge:          "5 years 9 months",
+		CurrentStreak:       15,
+		LongestStreak:       45,
 		WeeklyDistribution: map[string]int{
 			"Monday":    10,
 			"Tuesday":   8,
@@ -91,6 +92,36 @@ func TestMarkdownGeneration(t *testing.T) {
 	if !strings.Contains(markdown, "<details>") {
 		t.Error("Expected collapsible details section")
 	}
+
+	// Badges and breakdown show the same rounded percentages
+	if !strings.Contains(markdown, "Go-66.67%25") || !strings.Contains(markdown, " 66.67%") || !strings.Contains(markdown, " 33.33%") {
+		t.Error("Expected display percentages at the configured precision")
+	}
+}
+
+func TestTimeWindowIsShown(t *testing.T) {
+	gen := presentation.NewMarkdownGenerator(false)
+	stats := &domain.ProfileStats{
+		WeeklyDistribution: map[string]int{},
+		Window: domain.TimeWindow{
+			Since: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			Until: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	if markdown := gen.Generate(stats); !strings.Contains(markdown, "Activity from Jan 1, 2023 to Dec 31, 2023") {
+		t.Errorf("Expected the inclusive window in the header, got:\n%s", markdown)
+	}
+
+	stats.Window.Until = time.Time{}
+	if markdown := gen.Generate(stats); !strings.Contains(markdown, "Activity since Jan 1, 2023") {
+		t.Error("Expected an open-ended window")
+	}
+
+	stats.Window = domain.TimeWindow{}
+	if markdown := gen.Generate(stats); strings.Contains(markdown, "📆") {
+		t.Error("Expected no window line for the whole history")
+	}
 }
 
 func TestProgressBarGeneration(t *testing.T) {
@@ -108,3 +139,346 @@ func TestProgressBarGeneration(t *testing.T) {
 		t.Error("Expected full progress bar for 100%")
 	}
 }
+
+func TestOrganizationMarkdownGeneration(t *testing.T) {
+	stats := &domain.OrganizationStats{
+		Summary: &domain.ProfileStats{
+			Username:            "acme",
+			WeeklyDistribution:  map[string]int{},
+			LastUpdated:         time.Date(2023, 11, 15, 12, 0, 0, 0, time.UTC),
+			PercentagePrecision: 1,
+		},
+		Repositories: 4,
+		Members: []domain.MemberStats{
+			{
+				Login:        "alice",
+				Commits:      42,
+				Repositories: 3,
+				Languages: []domain.LanguageStats{
+					{Language: "Go", Percentage: 79.96, DisplayPercentage: 80},
+					{Language: "Other", Percentage: 14.97, DisplayPercentage: 14.9},
+					{Language: "Shell", Percentage: 5.07, DisplayPercentage: 5.1},
+				},
+				LastCommit: time.Date(2023, 11, 14, 9, 0, 0, 0, time.UTC),
+			},
+			{Login: "bob"},
+		},
+	}
+
+	gen := presentation.NewMarkdownGenerator(false)
+	markdown := gen.GenerateOrganization(stats)
+
+	if !strings.Contains(markdown, "Top Contributors") {
+		t.Error("Expected contributors section")
+	}
+	if !strings.Contains(markdown, "| 🥇 | [@alice](https://github.com/alice) | 42 | 3 | 🔵 Go 80.0%, 🐚 Shell 5.1% | 2023-11-14 |") {
+		t.Errorf("Expected ranked row for alice, got:\n%s", markdown)
+	}
+	if strings.Contains(markdown, "@bob") || !strings.Contains(markdown, "1 members without commits") {
+		t.Error("Expected members without commits to be counted, not listed")
+	}
+	if strings.Index(markdown, "Top Contributors") > strings.Index(markdown, "Last updated") {
+		t.Error("Expected contributors before the footer")
+	}
+}
+
+func TestYearReviewMarkdownGeneration(t *testing.T) {
+	review := &domain.YearReview{
+		Username:            "octo",
+		Year:                2023,
+		YearTotals:          domain.YearTotals{Commits: 150, ActiveDays: 80, LongestStreak: 12, Repositories: 4},
+		Previous:            domain.YearTotals{Commits: 100, ActiveDays: 90, LongestStreak: 12},
+		BusiestMonth:        time.March,
+		BusiestMonthCommits: 30,
+		BusiestWeekStart:    time.Date(2023, 3, 6, 0, 0, 0, 0, time.UTC),
+		BusiestWeekCommits:  12,
+		LongestStreakStart:  time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+		NewLanguages:        []string{"Rust"},
+		TopRepositories:     []domain.RepositoryActivity{{Name: "octo/api", Commits: 90}},
+		LastUpdated:         time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	gen := presentation.NewMarkdownGenerator(false)
+	markdown := gen.GenerateYearReview(review)
+
+	for _, want := range []string{
+		"<!--START_SECTION:GitInsights-YearReview-->",
+		"<!--END_SECTION:GitInsights-YearReview-->",
+		"2023 in Review",
+		"| 📝 Commits | 150 | 100 | 📈 +50% |",
+		"| 📅 Active days | 80 | 90 | 📉 -11% |",
+		"| 🔥 Longest streak | 12 | 12 | ➖ 0% |",
+		"| 📦 Repositories | 4 | 0 | 🆕 |",
+		"**Busiest month:** March with 30 commits",
+		"**Busiest week:** week of Mar 6 with 12 commits",
+		"**New languages:** 🦀 Rust",
+		"| 🥇 | octo/api | 90 |",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in year review, got:\n%s", want, markdown)
+		}
+	}
+	if strings.Contains(markdown, "START_SECTION:GitInsights-->") {
+		t.Error("Expected the year review not to use the profile section")
+	}
+}
+
+func TestTrendAndHistory(t *testing.T) {
+	gen := presentation.NewMarkdownGenerator(false)
+	stats := &domain.ProfileStats{
+		Username:           "octo",
+		TotalCommits:       1312,
+		LongestStreak:      12,
+		WeeklyDistribution: map[string]int{},
+		Languages:          []domain.LanguageStats{{Language: "Go", Percentage: 59.6, DisplayPercentage: 60}, {Language: "Python", Percentage: 40.4, DisplayPercentage: 40}},
+		LastUpdated:        time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Trend: &domain.Trend{
+			Since:         time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC),
+			Commits:       312,
+			CurrentStreak: -1,
+			LongestStreak: 3,
+			Languages:     []domain.LanguageTrend{{Language: "Go", Change: 4.2}, {Language: "Python", Change: -4.2}},
+		},
+	}
+
+	markdown := gen.Generate(stats)
+	if !strings.Contains(markdown, "📊 Since Feb 1, 2024: +312 commits · current streak dropped by 1 day · longest streak improved by 3 days · 🔵 Go +4.2% · 🐍 Python -4.2%") {
+		t.Errorf("Expected the trend line, got:\n%s", markdown)
+	}
+
+	stats.Trend = &domain.Trend{Since: stats.Trend.Since, Commits: -2, CurrentStreak: 4, LongestStreak: -5}
+	markdown = gen.Generate(stats)
+	if !strings.Contains(markdown, "📊 Since Feb 1, 2024: -2 commits · current streak improved by 4 days · longest streak dropped by 5 days") {
+		t.Errorf("Expected decreases in words, got:\n%s", markdown)
+	}
+
+	history := gen.GenerateHistory([]domain.ProfileStats{*stats})
+	if !strings.Contains(history, "| 2024-03-01 12:00 | octo | 1312 | 0 | 12 | 🔵 Go 60%, 🐍 Python 40% |") {
+		t.Errorf("Expected a history row, got:\n%s", history)
+	}
+}
+
+// testCalendar starts on Sunday, March 12, 2023 and ends on Wednesday,
+// March 13, 2024, with one commit on its first day and four on its last
+func testCalendar() *domain.ContributionCalendar {
+	calendar := &domain.ContributionCalendar{Total: 5, Quartiles: [3]int{1, 1, 4}}
+	start := time.Date(2023, 3, 12, 0, 0, 0, 0, time.UTC)
+	for week := 0; week < domain.CalendarWeeks; week++ {
+		for weekday := 0; weekday < 7; weekday++ {
+			if week == domain.CalendarWeeks-1 && weekday > 3 {
+				break
+			}
+			calendar.Weeks[week][weekday].Date = start.AddDate(0, 0, 7*week+weekday)
+		}
+	}
+	calendar.Weeks[0][0].Count, calendar.Weeks[0][0].Level = 1, 1
+	calendar.Weeks[domain.CalendarWeeks-1][3].Count, calendar.Weeks[domain.CalendarWeeks-1][3].Level = 4, 4
+	return calendar
+}
+
+func TestContributionCalendarHeatmap(t *testing.T) {
+	stats := &domain.ProfileStats{
+		Username:           "octo",
+		WeeklyDistribution: map[string]int{},
+		LastUpdated:        time.Date(2024, 3, 13, 12, 0, 0, 0, time.UTC),
+		Calendar:           testCalendar(),
+	}
+
+	markdown := presentation.NewMarkdownGenerator(false).Generate(stats)
+	if !strings.Contains(markdown, "## 🗓️ Contribution Calendar") {
+		t.Fatalf("Expected the calendar section, got:\n%s", markdown)
+	}
+
+	lines := strings.Split(markdown, "\n")
+	var rows []string
+	for i, line := range lines {
+		if strings.HasPrefix(line, "Mon ") {
+			rows = lines[i-2 : i+6]
+			break
+		}
+	}
+	if rows == nil {
+		t.Fatalf("Expected heatmap rows, got:\n%s", markdown)
+	}
+
+	// Month labels, then Sunday to Saturday. March is left out of the first
+	// column because April starts three weeks later.
+	if !strings.HasPrefix(rows[0], "       Apr  May") || strings.Contains(rows[0], "Mar") {
+		t.Errorf("Expected month labels, got: %q", rows[0])
+	}
+	if sunday := []rune(rows[1]); len(sunday) != 4+domain.CalendarWeeks || sunday[4] != '░' {
+		t.Errorf("Expected 53 Sunday cells starting at level 1, got: %q", rows[1])
+	}
+	if wednesday := []rune(rows[4]); wednesday[len(wednesday)-1] != '█' {
+		t.Errorf("Expected today at level 4, got: %q", rows[4])
+	}
+	if saturday := []rune(rows[7]); len(saturday) != 4+domain.CalendarWeeks-1 {
+		t.Errorf("Expected no Saturday cell after today, got: %q", rows[7])
+	}
+	if !strings.Contains(markdown, "5 contributions in the last year    Less · ░ ▒ ▓ █ More") {
+		t.Errorf("Expected the total and legend, got:\n%s", markdown)
+	}
+}
+
+func TestContributionCalendarSVG(t *testing.T) {
+	gen := presentation.NewMarkdownGenerator(false, presentation.WithCalendarSVG("assets/calendar.svg"))
+	stats := &domain.ProfileStats{
+		Username:           "octo",
+		WeeklyDistribution: map[string]int{},
+		LastUpdated:        time.Date(2024, 3, 13, 12, 0, 0, 0, time.UTC),
+		Calendar:           testCalendar(),
+	}
+
+	markdown := gen.Generate(stats)
+	if !strings.Contains(markdown, `<img src="assets/calendar.svg" alt="5 contributions in the last year"/>`) {
+		t.Errorf("Expected the calendar image, got:\n%s", markdown)
+	}
+	if strings.Contains(markdown, "Less · ░") {
+		t.Errorf("Expected no text heatmap next to the image, got:\n%s", markdown)
+	}
+
+	svg := gen.GenerateCalendarSVG(stats.Calendar)
+	if !strings.HasPrefix(svg, "<svg xmlns=\"http://www.w3.org/2000/svg\"") || !strings.HasSuffix(svg, "</svg>\n") {
+		t.Errorf("Expected a standalone SVG document, got:\n%s", svg)
+	}
+	// 7 cells a week except the last 3 days, plus 5 legend cells
+	if cells := strings.Count(svg, "<rect "); cells != domain.CalendarWeeks*7-3+5 {
+		t.Errorf("Expected %d cells, got: %d", domain.CalendarWeeks*7-3+5, cells)
+	}
+	if !strings.Contains(svg, `fill="#216e39"><title>4 contributions on Wednesday, March 13, 2024</title>`) {
+		t.Errorf("Expected a level 4 cell with a tooltip, got:\n%s", svg)
+	}
+	if !strings.Contains(svg, `fill="#9be9a8"><title>1 contribution on Sunday, March 12, 2023</title>`) {
+		t.Errorf("Expected a level 1 cell with a tooltip, got:\n%s", svg)
+	}
+}
+
+func TestPunchCard(t *testing.T) {
+	stats := &domain.ProfileStats{
+		Username:           "octo",
+		WeeklyDistribution: map[string]int{},
+		LastUpdated:        time.Date(2024, 3, 13, 12, 0, 0, 0, time.UTC),
+		WorkingWindow: &domain.WorkingWindow{
+			FirstDay: time.Monday, LastDay: time.Friday, StartHour: 22, EndHour: 2, Commits: 9, Share: 61.5,
+		},
+	}
+	stats.PunchCard[time.Monday][9] = 8
+	stats.PunchCard[time.Sunday][23] = 1
+
+	markdown := presentation.NewMarkdownGenerator(false).Generate(stats)
+	if !strings.Contains(markdown, "## 🕐 Punch Card") {
+		t.Fatalf("Expected the punch card section, got:\n%s", markdown)
+	}
+	if !strings.Contains(markdown, "\n    00    03    06    09    12    15    18    21\n") {
+		t.Errorf("Expected hour labels every three hours, got:\n%s", markdown)
+	}
+	if !strings.Contains(markdown, "\nMon · · · · · · · · · █ · · · · · · · · · · · · · ·\n") {
+		t.Errorf("Expected the busiest cell at the highest level, got:\n%s", markdown)
+	}
+	if !strings.Contains(markdown, "\nSun · · · · · · · · · · · · · · · · · · · · · · · ░\n") {
+		t.Errorf("Expected a quiet cell at the lowest level, got:\n%s", markdown)
+	}
+	if !strings.Contains(markdown, "🕘 <b>Typical working window:</b> Mon–Fri, 22:00–02:00 · 62% of commits") {
+		t.Errorf("Expected the typical working window, got:\n%s", markdown)
+	}
+
+	svg := presentation.NewMarkdownGenerator(false).GeneratePunchCardSVG(stats.PunchCard, stats.WorkingWindow)
+	if strings.Count(svg, "<circle ") != 2 {
+		t.Errorf("Expected a circle per active cell, got:\n%s", svg)
+	}
+	if !strings.Contains(svg, `r="10.0" fill="#216e39"><title>8 commits on Mondays at 09:00</title>`) ||
+		!strings.Contains(svg, `<title>1 commit on Sundays at 23:00</title>`) {
+		t.Errorf("Expected circles sized by commits with tooltips, got:\n%s", svg)
+	}
+	// The window spans midnight, so it is outlined in two parts
+	if strings.Count(svg, `stroke="#40c463"`) != 2 {
+		t.Errorf("Expected the working window outlined in two parts, got:\n%s", svg)
+	}
+}
+
+func TestPunchCardWithoutTimes(t *testing.T) {
+	stats := &domain.ProfileStats{
+		Username:           "octo",
+		WeeklyDistribution: map[string]int{},
+		LastUpdated:        time.Date(2024, 3, 13, 12, 0, 0, 0, time.UTC),
+	}
+	if markdown := presentation.NewMarkdownGenerator(false).Generate(stats); strings.Contains(markdown, "Punch Card") {
+		t.Errorf("Expected no punch card without commit times, got:\n%s", markdown)
+	}
+}
+
+func TestCommitTrends(t *testing.T) {
+	month := func(m time.Month, commits, change int, average float64) domain.PeriodCommits {
+		return domain.PeriodCommits{Start: time.Date(2024, m, 1, 0, 0, 0, 0, time.UTC), Commits: commits, Change: change, MovingAverage: average}
+	}
+	stats := &domain.ProfileStats{
+		Username:           "octo",
+		WeeklyDistribution: map[string]int{},
+		LastUpdated:        time.Date(2024, 4, 13, 12, 0, 0, 0, time.UTC),
+		MonthlyCommits: []domain.PeriodCommits{
+			month(time.January, 0, 0, 0),
+			month(time.February, 1, 1, 0.5),
+			month(time.March, 20, 19, 7),
+			month(time.April, 25, 5, 15.3),
+		},
+		YearlyCommits: []domain.PeriodCommits{
+			{Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), Commits: 30, MovingAverage: 30},
+			{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Commits: 46, Change: 16, MovingAverage: 38},
+		},
+	}
+
+	markdown := presentation.NewMarkdownGenerator(false).Generate(stats)
+	if !strings.Contains(markdown, "Monthly  ▁▂▆█  Jan 2024 – Apr 2024") {
+		t.Errorf("Expected the monthly sparkline, got:\n%s", markdown)
+	}
+	if !strings.Contains(markdown, "Yearly   ▅█  2023 – 2024") {
+		t.Errorf("Expected the yearly sparkline, got:\n%s", markdown)
+	}
+	if !strings.Contains(markdown, "<b>Apr 2024:</b> 25 commits · 📈 +25% vs Mar · 3-month average 15.3, 📈 rising") {
+		t.Errorf("Expected the month over month trend, got:\n%s", markdown)
+	}
+	if !strings.Contains(markdown, "Apr 2024 ██████████████████████████████    25 commits  avg    15.3") {
+		t.Errorf("Expected a bar for April, got:\n%s", markdown)
+	}
+	if !strings.Contains(markdown, "2023     ▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓░░░░░░░░░░░    30 commits  avg    30.0") {
+		t.Errorf("Expected a bar for 2023, got:\n%s", markdown)
+	}
+}
+
+func TestLanguageEvolution(t *testing.T) {
+	year := func(year, commits int, python, golang, other float64) domain.YearLanguages {
+		return domain.YearLanguages{Year: year, Commits: commits, Languages: []domain.LanguageStats{
+			{Language: "Python", DisplayPercentage: python},
+			{Language: "Go", DisplayPercentage: golang},
+			{Language: "Other", DisplayPercentage: other},
+		}}
+	}
+	stats := &domain.ProfileStats{
+		Username:            "octo",
+		WeeklyDistribution:  map[string]int{},
+		LastUpdated:         time.Date(2024, 3, 13, 12, 0, 0, 0, time.UTC),
+		PercentagePrecision: 1,
+		LanguageEvolution: []domain.YearLanguages{
+			year(2019, 120, 90, 0, 10),
+			year(2023, 340, 22.5, 72.5, 5),
+		},
+	}
+
+	markdown := presentation.NewMarkdownGenerator(false).Generate(stats)
+	for _, line := range []string{
+		"| Year | 🟦 Python | 🟩 Go | ⬜ Other | Mix | Commits |",
+		"| 2019 | 90.0% | — | 10.0% | 🟦🟦🟦🟦🟦🟦🟦🟦🟦⬜ | 120 |",
+		// 2.25 and 7.25 squares round down, the last one goes to Other's 0.5
+		"| 2023 | 22.5% | 72.5% | 5.0% | 🟦🟦🟩🟩🟩🟩🟩🟩🟩⬜ | 340 |",
+	} {
+		if !strings.Contains(markdown, line) {
+			t.Errorf("Expected %q, got:\n%s", line, markdown)
+		}
+	}
+
+	// A single year has nothing to compare with
+	stats.LanguageEvolution = stats.LanguageEvolution[:1]
+	if markdown := presentation.NewMarkdownGenerator(false).Generate(stats); strings.Contains(markdown, "Language Evolution") {
+		t.Errorf("Expected no evolution for a single year, got:\n%s", markdown)
+	}
+}
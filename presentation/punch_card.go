@@ -0,0 +1,161 @@
+package presentation
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"GitInsights/domain"
+)
+
+// punchCardDays are the rows of a punch card, Monday first
+var punchCardDays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+
+// punchCardSection renders the punch card as a grid of weekdays by hours, or
+// as an image when an SVG path is configured, followed by the typical
+// working window
+func (m *MarkdownGenerator) punchCardSection(stats *domain.ProfileStats) []string {
+	var lines []string
+
+	lines = append(lines, "<div align=\"center\">")
+	lines = append(lines, "")
+	lines = append(lines, "## 🕐 Punch Card")
+	lines = append(lines, "")
+
+	if m.punchCardSVGPath != "" {
+		lines = append(lines, fmt.Sprintf("<img src=\"%s\" alt=\"Commits by weekday and hour\"/>", m.punchCardSVGPath))
+		lines = append(lines, "")
+		lines = append(lines, m.workingWindowLabel(stats.WorkingWindow))
+		lines = append(lines, "")
+		lines = append(lines, "</div>")
+		lines = append(lines, "")
+		return lines
+	}
+
+	lines = append(lines, "</div>")
+	lines = append(lines, "")
+	lines = append(lines, "```text")
+
+	// Every third hour above its column, two characters per hour
+	header := "    "
+	for hour := 0; hour < 24; hour += 3 {
+		header += fmt.Sprintf("%02d    ", hour)
+	}
+	lines = append(lines, strings.TrimRight(header, " "))
+
+	maxCount := punchCardMax(stats.PunchCard)
+	for _, weekday := range punchCardDays {
+		cells := make([]string, 24)
+		for hour, count := range stats.PunchCard[weekday] {
+			cells[hour] = string(calendarLevelChars[punchCardLevel(count, maxCount)])
+		}
+		lines = append(lines, fmt.Sprintf("%-4s%s", weekday.String()[:3], strings.Join(cells, " ")))
+	}
+
+	lines = append(lines, "```")
+	lines = append(lines, "")
+	lines = append(lines, "<div align=\"center\">")
+	lines = append(lines, "")
+	lines = append(lines, m.workingWindowLabel(stats.WorkingWindow))
+	lines = append(lines, "")
+	lines = append(lines, "</div>")
+	lines = append(lines, "")
+
+	return lines
+}
+
+// workingWindowLabel describes the typical working window, such as
+// "Mon–Fri, 09:00–18:00"
+func (m *MarkdownGenerator) workingWindowLabel(window *domain.WorkingWindow) string {
+	days := window.FirstDay.String()[:3]
+	if window.LastDay != window.FirstDay {
+		days += "–" + window.LastDay.String()[:3]
+	}
+	return fmt.Sprintf("🕘 <b>Typical working window:</b> %s, %02d:00–%02d:00 · %.0f%% of commits", days, window.StartHour, window.EndHour, window.Share)
+}
+
+// punchCardMax returns the highest count of a punch card
+func punchCardMax(card domain.PunchCard) int {
+	maxCount := 0
+	for _, hours := range card {
+		for _, count := range hours {
+			if count > maxCount {
+				maxCount = count
+			}
+		}
+	}
+	return maxCount
+}
+
+// punchCardLevel maps a count to a level from 0 to 4 relative to the busiest
+// cell
+func punchCardLevel(count, maxCount int) int {
+	if count == 0 || maxCount == 0 {
+		return 0
+	}
+	return (count*4 + maxCount - 1) / maxCount
+}
+
+// GeneratePunchCardSVG renders the punch card as a self-contained SVG image:
+// one circle per weekday and hour, sized by its commits, with the typical
+// working window outlined
+func (m *MarkdownGenerator) GeneratePunchCardSVG(card domain.PunchCard, window *domain.WorkingWindow) string {
+	const (
+		step      = 24
+		maxRadius = 10
+		left      = 36
+		top       = 20
+		fontCSS   = "font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Helvetica,Arial,sans-serif;font-size:10px;fill:#57606a"
+	)
+	width := left + 24*step
+	height := top + 7*step + 4
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n", width, height, width, height)
+	fmt.Fprintf(&b, "<style>text{%s}</style>\n", fontCSS)
+
+	for hour := 0; hour < 24; hour += 3 {
+		fmt.Fprintf(&b, "<text x=\"%d\" y=\"%d\" text-anchor=\"middle\">%02d</text>\n", left+hour*step+step/2, top-8, hour)
+	}
+
+	// Outline the working window, in two parts when it spans midnight
+	if window != nil {
+		first, last := punchCardRow(window.FirstDay), punchCardRow(window.LastDay)
+		y := top + first*step
+		h := (last - first + 1) * step
+		outline := func(start, end int) {
+			fmt.Fprintf(&b, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" rx=\"4\" fill=\"#dafbe1\" stroke=\"#40c463\"/>\n", left+start*step, y, (end-start)*step, h)
+		}
+		if window.EndHour > window.StartHour {
+			outline(window.StartHour, window.EndHour)
+		} else {
+			outline(window.StartHour, 24)
+			if window.EndHour > 0 {
+				outline(0, window.EndHour)
+			}
+		}
+	}
+
+	maxCount := punchCardMax(card)
+	for row, weekday := range punchCardDays {
+		cy := top + row*step + step/2
+		fmt.Fprintf(&b, "<text x=\"0\" y=\"%d\" dominant-baseline=\"middle\">%s</text>\n", cy, weekday.String()[:3])
+		for hour, count := range card[weekday] {
+			if count == 0 {
+				continue
+			}
+			radius := maxRadius * math.Sqrt(float64(count)/float64(maxCount))
+			fmt.Fprintf(&b, "<circle cx=\"%d\" cy=\"%d\" r=\"%.1f\" fill=\"%s\"><title>%s on %ss at %02d:00</title></circle>\n",
+				left+hour*step+step/2, cy, math.Max(radius, 1.5), calendarLevelColors[punchCardLevel(count, maxCount)], countOf(count, "commit"), weekday, hour)
+		}
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// punchCardRow returns the row of a weekday, Monday first
+func punchCardRow(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
@@ -0,0 +1,113 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+
+	"GitInsights/domain"
+)
+
+// Marker sections the generated content replaces
+const (
+	ProfileSection    = "GitInsights"
+	YearReviewSection = "GitInsights-YearReview"
+)
+
+// GenerateYearReview creates markdown content for a year in review, meant
+// for its own marker section
+func (m *MarkdownGenerator) GenerateYearReview(review *domain.YearReview) string {
+	var lines []string
+
+	lines = append(lines, "<!--START_SECTION:"+YearReviewSection+"-->")
+	lines = append(lines, "")
+	lines = append(lines, "<div align=\"center\">")
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("## 🗓️ %d in Review", review.Year))
+	lines = append(lines, "")
+	lines = append(lines, "</div>")
+	lines = append(lines, "")
+
+	// Headline numbers compared with the previous year
+	lines = append(lines, fmt.Sprintf("| | %d | %d | Change |", review.Year, review.Year-1))
+	lines = append(lines, "|:---|---:|---:|:---:|")
+	rows := []struct {
+		label           string
+		current, before int
+	}{
+		{"📝 Commits", review.Commits, review.Previous.Commits},
+		{"📅 Active days", review.ActiveDays, review.Previous.ActiveDays},
+		{"🔥 Longest streak", review.LongestStreak, review.Previous.LongestStreak},
+		{"📦 Repositories", review.Repositories, review.Previous.Repositories},
+	}
+	for _, row := range rows {
+		lines = append(lines, fmt.Sprintf("| %s | %d | %d | %s |", row.label, row.current, row.before, m.periodChange(row.current, row.before)))
+	}
+	lines = append(lines, "")
+
+	// Highlights
+	if review.Commits > 0 {
+		lines = append(lines, "<div align=\"center\">")
+		lines = append(lines, "")
+		lines = append(lines, "### ✨ Highlights")
+		lines = append(lines, "")
+		lines = append(lines, "</div>")
+		lines = append(lines, "")
+		lines = append(lines, fmt.Sprintf("- **Busiest month:** %s with %d commits", review.BusiestMonth, review.BusiestMonthCommits))
+		lines = append(lines, fmt.Sprintf("- **Busiest week:** week of %s with %d commits", review.BusiestWeekStart.Format("Jan 2"), review.BusiestWeekCommits))
+		lines = append(lines, fmt.Sprintf("- **Longest streak:** %d days from %s", review.LongestStreak, review.LongestStreakStart.Format("Jan 2")))
+		if len(review.NewLanguages) > 0 {
+			var languages []string
+			for _, lang := range review.NewLanguages {
+				languages = append(languages, m.getLanguageEmoji(lang)+" "+lang)
+			}
+			lines = append(lines, "- **New languages:** "+strings.Join(languages, ", "))
+		}
+		lines = append(lines, "")
+	}
+
+	if len(review.TopRepositories) > 0 {
+		lines = append(lines, "<div align=\"center\">")
+		lines = append(lines, "")
+		lines = append(lines, "### 🏆 Top Repositories")
+		lines = append(lines, "")
+		lines = append(lines, "</div>")
+		lines = append(lines, "")
+		lines = append(lines, "| Rank | Repository | Commits |")
+		lines = append(lines, "|:---:|:---|---:|")
+		for i, repo := range review.TopRepositories {
+			lines = append(lines, fmt.Sprintf("| %s | %s | %d |", m.getRankBadge(i+1), repo.Name, repo.Commits))
+		}
+		lines = append(lines, "")
+	}
+
+	// Footer
+	lines = append(lines, "<div align=\"center\">")
+	lines = append(lines, "")
+	lines = append(lines, "<sub>📅 Last updated: "+review.LastUpdated.Format("Monday, January 2, 2006 at 3:04 PM")+"</sub>")
+	if m.showCredit {
+		lines = append(lines, "")
+		lines = append(lines, "<sub>⚡ Generated with [GitInsights](https://github.com/awcodify/GitInsights)</sub>")
+	}
+	lines = append(lines, "")
+	lines = append(lines, "</div>")
+	lines = append(lines, "")
+	lines = append(lines, "<!--END_SECTION:"+YearReviewSection+"-->")
+
+	return strings.Join(lines, "\n")
+}
+
+// periodChange describes the change from the previous period
+func (m *MarkdownGenerator) periodChange(current, before int) string {
+	switch {
+	case before == 0 && current == 0:
+		return "—"
+	case before == 0:
+		return "🆕"
+	case current > before:
+		return fmt.Sprintf("📈 +%.0f%%", float64(current-before)/float64(before)*100)
+	case current < before:
+		return fmt.Sprintf("📉 %.0f%%", float64(current-before)/float64(before)*100)
+	default:
+		return "➖ 0%"
+	}
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"time"
+
+	"GitInsights/domain"
+)
+
+// movingAveragePeriods is the number of periods a moving average spans
+const movingAveragePeriods = 3
+
+// calculateCommitSeries counts commits per month and per year, from the
+// period of the first commit up to the current one
+func (uc *ProfileStatsUseCase) calculateCommitSeries(commits []domain.Commit) ([]domain.PeriodCommits, []domain.PeriodCommits) {
+	if len(commits) == 0 {
+		return nil, nil
+	}
+
+	first, last := commits[0].Date, commits[0].Date
+	for _, commit := range commits {
+		if commit.Date.Before(first) {
+			first = commit.Date
+		}
+		if commit.Date.After(last) {
+			last = commit.Date
+		}
+	}
+	if now := uc.now(last.Location()); now.After(last) {
+		last = now
+	}
+
+	monthStart := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	yearStart := func(t time.Time) time.Time {
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	monthly := commitSeries(commits, monthStart(first), monthStart(last), monthStart, 0, 1)
+	yearly := commitSeries(commits, yearStart(first), yearStart(last), yearStart, 1, 0)
+	return monthly, yearly
+}
+
+// commitSeries counts commits per period from the one starting at first to
+// the one starting at last. period returns the start of a commit's period,
+// and periods are years and months long.
+func commitSeries(commits []domain.Commit, first, last time.Time, period func(time.Time) time.Time, years, months int) []domain.PeriodCommits {
+	counts := make(map[time.Time]int)
+	for _, commit := range commits {
+		counts[period(commit.Date)]++
+	}
+
+	var series []domain.PeriodCommits
+	for start := first; !start.After(last); start = start.AddDate(years, months, 0) {
+		current := domain.PeriodCommits{Start: start, Commits: counts[start]}
+		if n := len(series); n > 0 {
+			current.Change = current.Commits - series[n-1].Commits
+		}
+
+		// Mean of this period and the ones before it, up to the span
+		sum, periods := current.Commits, 1
+		for i := len(series) - 1; i >= 0 && periods < movingAveragePeriods; i-- {
+			sum += series[i].Commits
+			periods++
+		}
+		current.MovingAverage = float64(sum) / float64(periods)
+
+		series = append(series, current)
+	}
+	return series
+}
@@ -0,0 +1,73 @@
+package usecase_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"GitInsights/domain"
+	"GitInsights/usecase"
+)
+
+func TestCommitSeries(t *testing.T) {
+	at := func(year int, month time.Month, n int) []domain.Commit {
+		var commits []domain.Commit
+		for i := 0; i < n; i++ {
+			commits = append(commits, domain.Commit{Date: time.Date(year, month, 10, 12, i, 0, 0, time.UTC)})
+		}
+		return commits
+	}
+
+	var commits []domain.Commit
+	commits = append(commits, at(2023, 11, 3)...)
+	commits = append(commits, at(2024, 1, 6)...)
+	commits = append(commits, at(2024, 2, 9)...)
+
+	mockRepo := &MockGitHubRepository{
+		Username: "testuser",
+		UserProfile: &domain.UserProfile{
+			Username:  "testuser",
+			CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Commits: commits,
+	}
+
+	// The window ends in March 2024, a month without commits
+	window := domain.TimeWindow{Until: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)}
+	uc := usecase.NewProfileStatsUseCase(mockRepo, 10, "", usecase.WithLocation(time.UTC), usecase.WithTimeWindow(window))
+	stats, err := uc.GetProfileStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := []struct {
+		month   time.Month
+		year    int
+		commits int
+		change  int
+		average float64
+	}{
+		{time.November, 2023, 3, 0, 3},
+		{time.December, 2023, 0, -3, 1.5},
+		{time.January, 2024, 6, 6, 3},
+		{time.February, 2024, 9, 3, 5},
+		{time.March, 2024, 0, -9, 5},
+	}
+	if len(stats.MonthlyCommits) != len(expected) {
+		t.Fatalf("Expected %d months, got: %+v", len(expected), stats.MonthlyCommits)
+	}
+	for i, want := range expected {
+		got := stats.MonthlyCommits[i]
+		if !got.Start.Equal(time.Date(want.year, want.month, 1, 0, 0, 0, 0, time.UTC)) ||
+			got.Commits != want.commits || got.Change != want.change || math.Abs(got.MovingAverage-want.average) > 0.001 {
+			t.Errorf("Expected %s %d with %d commits, change %d and average %.1f, got: %+v",
+				want.month, want.year, want.commits, want.change, want.average, got)
+		}
+	}
+
+	if len(stats.YearlyCommits) != 2 || stats.YearlyCommits[0].Commits != 3 || stats.YearlyCommits[1].Commits != 15 ||
+		stats.YearlyCommits[1].Change != 12 || stats.YearlyCommits[1].MovingAverage != 9 {
+		t.Errorf("Expected 3 commits in 2023 and 15 in 2024, got: %+v", stats.YearlyCommits)
+	}
+}
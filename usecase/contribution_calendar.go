@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"sort"
+	"time"
+
+	"GitInsights/domain"
+)
+
+// calculateContributionCalendar lays out the daily commit counts of the year
+// ending today. Like GitHub, the first column starts on the Sunday 52 weeks
+// before the current week, and levels split the days with commits into
+// quartiles.
+func (uc *ProfileStatsUseCase) calculateContributionCalendar(commits []domain.Commit) *domain.ContributionCalendar {
+	fallback := time.UTC
+	if len(commits) > 0 {
+		fallback = commits[len(commits)-1].Date.Location()
+	}
+	today := calendarDay(uc.now(fallback))
+	start := today.AddDate(0, 0, -int(today.Weekday())-7*(domain.CalendarWeeks-1))
+
+	counts := make(map[time.Time]int)
+	for _, commit := range commits {
+		day := calendarDay(commit.Date)
+		if !day.Before(start) && !day.After(today) {
+			counts[day]++
+		}
+	}
+
+	calendar := &domain.ContributionCalendar{}
+	var active []int
+	for week := 0; week < domain.CalendarWeeks; week++ {
+		for weekday := 0; weekday < 7; weekday++ {
+			day := start.AddDate(0, 0, 7*week+weekday)
+			if day.After(today) {
+				continue
+			}
+			count := counts[day]
+			calendar.Weeks[week][weekday] = domain.ContributionDay{Date: day, Count: count}
+			calendar.Total += count
+			if count > 0 {
+				active = append(active, count)
+			}
+		}
+	}
+
+	if len(active) == 0 {
+		return calendar
+	}
+
+	// Quartiles of the days with commits decide the levels
+	sort.Ints(active)
+	for i := range calendar.Quartiles {
+		calendar.Quartiles[i] = active[(len(active)-1)*(i+1)/4]
+	}
+	for week := range calendar.Weeks {
+		for weekday := range calendar.Weeks[week] {
+			cell := &calendar.Weeks[week][weekday]
+			cell.Level = contributionLevel(cell.Count, calendar.Quartiles)
+		}
+	}
+
+	return calendar
+}
+
+// contributionLevel maps a day's count to its intensity level
+func contributionLevel(count int, quartiles [3]int) int {
+	if count == 0 {
+		return 0
+	}
+	for i, bound := range quartiles {
+		if count <= bound {
+			return i + 1
+		}
+	}
+	return 4
+}
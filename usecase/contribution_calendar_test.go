@@ -0,0 +1,81 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"GitInsights/domain"
+	"GitInsights/usecase"
+)
+
+func TestContributionCalendar(t *testing.T) {
+	// The window ends on Wednesday, March 13, 2024
+	window := domain.TimeWindow{Until: time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)}
+	at := func(year int, month time.Month, day, n int) []domain.Commit {
+		var commits []domain.Commit
+		for i := 0; i < n; i++ {
+			commits = append(commits, domain.Commit{Date: time.Date(year, month, day, 10, i, 0, 0, time.UTC)})
+		}
+		return commits
+	}
+
+	var commits []domain.Commit
+	commits = append(commits, at(2023, 3, 11, 9)...) // Saturday before the first column
+	commits = append(commits, at(2023, 3, 12, 1)...) // first cell
+	commits = append(commits, at(2023, 8, 1, 2)...)
+	commits = append(commits, at(2023, 8, 2, 3)...)
+	commits = append(commits, at(2024, 3, 13, 8)...) // today
+
+	mockRepo := &MockGitHubRepository{
+		Username: "testuser",
+		UserProfile: &domain.UserProfile{
+			Username:  "testuser",
+			CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Commits: commits,
+	}
+
+	uc := usecase.NewProfileStatsUseCase(mockRepo, 10, "", usecase.WithTimeWindow(window))
+	stats, err := uc.GetProfileStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	calendar := stats.Calendar
+	if calendar == nil {
+		t.Fatal("Expected a contribution calendar")
+	}
+	if calendar.Total != 14 {
+		t.Errorf("Expected 14 commits inside the calendar, got: %d", calendar.Total)
+	}
+
+	first := calendar.Weeks[0][0]
+	if !first.Date.Equal(time.Date(2023, 3, 12, 0, 0, 0, 0, time.UTC)) || first.Count != 1 {
+		t.Errorf("Expected the first cell on Sunday, March 12, 2023 with 1 commit, got: %+v", first)
+	}
+
+	last := calendar.Weeks[domain.CalendarWeeks-1]
+	if !last[3].Date.Equal(time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC)) || last[3].Count != 8 {
+		t.Errorf("Expected today in the last column, got: %+v", last[3])
+	}
+	if !last[4].Date.IsZero() {
+		t.Errorf("Expected no cells after today, got: %+v", last[4])
+	}
+
+	// Active days have 1, 2, 3 and 8 commits: one per level
+	if calendar.Quartiles != [3]int{1, 2, 3} {
+		t.Errorf("Expected quartiles 1, 2, 3, got: %v", calendar.Quartiles)
+	}
+	levels := map[int]int{}
+	for _, week := range calendar.Weeks {
+		for _, day := range week {
+			if day.Count > 0 {
+				levels[day.Count] = day.Level
+			}
+		}
+	}
+	if levels[1] != 1 || levels[2] != 2 || levels[3] != 3 || levels[8] != 4 {
+		t.Errorf("Expected levels 1-4 by quartile, got: %v", levels)
+	}
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"GitInsights/domain"
+)
+
+// TrendBaseline selects the snapshot trends are measured against
+type TrendBaseline string
+
+const (
+	// TrendSinceLastRun compares with the most recent snapshot
+	TrendSinceLastRun TrendBaseline = "last-run"
+	// TrendSinceLastMonth compares with the most recent snapshot that is at
+	// least a month old
+	TrendSinceLastMonth TrendBaseline = "last-month"
+)
+
+// ParseTrendBaseline returns the baseline with the given name
+func ParseTrendBaseline(name string) (TrendBaseline, error) {
+	switch baseline := TrendBaseline(name); baseline {
+	case TrendSinceLastRun, TrendSinceLastMonth:
+		return baseline, nil
+	}
+	return "", fmt.Errorf("unknown trend baseline %q (expected %s or %s)", name, TrendSinceLastRun, TrendSinceLastMonth)
+}
+
+// WithHistory compares the statistics with the snapshot in history selected
+// by baseline, and lets RecordSnapshot store them there
+func WithHistory(history domain.FileRepository, baseline TrendBaseline) Option {
+	return func(uc *ProfileStatsUseCase) {
+		uc.history = history
+		uc.trendBaseline = baseline
+	}
+}
+
+// setTrend sets the trend of stats against the stored snapshots of the same
+// user taken with the same settings
+func (uc *ProfileStatsUseCase) setTrend(stats *domain.ProfileStats) error {
+	snapshots, err := userSnapshots(uc.history, stats.Username)
+	if err != nil {
+		return err
+	}
+
+	if baseline := uc.baselineSnapshot(snapshots, stats); baseline != nil {
+		stats.Trend = compareSnapshots(baseline, stats)
+	}
+	return nil
+}
+
+// RecordSnapshot stores stats as the newest snapshot. Call it once the
+// output has been written, so failed runs don't become a baseline. It does
+// nothing without history.
+func (uc *ProfileStatsUseCase) RecordSnapshot(stats *domain.ProfileStats) error {
+	if uc.history == nil {
+		return nil
+	}
+	if err := uc.history.SaveSnapshot(stats); err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	return nil
+}
+
+// baselineSnapshot picks the snapshot to compare with, nil when none fits
+func (uc *ProfileStatsUseCase) baselineSnapshot(snapshots []domain.ProfileStats, stats *domain.ProfileStats) *domain.ProfileStats {
+	cutoff := stats.LastUpdated
+	if uc.trendBaseline == TrendSinceLastMonth {
+		cutoff = cutoff.AddDate(0, -1, 0)
+	}
+
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if !snapshots[i].LastUpdated.After(cutoff) && sameSettings(&snapshots[i], stats) {
+			return &snapshots[i]
+		}
+	}
+	return nil
+}
+
+// sameSettings reports whether snapshot covers the same window with the same
+// language weighting as stats. Windows given relative to now compare by how
+// they were described, so rolling windows match from run to run.
+func sameSettings(snapshot, stats *domain.ProfileStats) bool {
+	if snapshot.Weighting != stats.Weighting {
+		return false
+	}
+	if snapshot.Window.Spec != "" || stats.Window.Spec != "" {
+		return snapshot.Window.Spec == stats.Window.Spec
+	}
+	return snapshot.Window.Since.Equal(stats.Window.Since) && snapshot.Window.Until.Equal(stats.Window.Until)
+}
+
+// compareSnapshots describes how current changed since previous. Language
+// shares are compared by name, so "Other" is left out.
+func compareSnapshots(previous, current *domain.ProfileStats) *domain.Trend {
+	trend := &domain.Trend{
+		Since:         previous.LastUpdated,
+		Commits:       current.TotalCommits - previous.TotalCommits,
+		CurrentStreak: current.CurrentStreak - previous.CurrentStreak,
+		LongestStreak: current.LongestStreak - previous.LongestStreak,
+	}
+
+	before := make(map[string]float64)
+	for _, lang := range previous.Languages {
+		before[lang.Language] = lang.Percentage
+	}
+	for _, lang := range current.Languages {
+		if lang.Language == "Other" {
+			continue
+		}
+		// Ignore changes too small to show with one decimal
+		if change := lang.Percentage - before[lang.Language]; math.Abs(change) >= 0.05 {
+			trend.Languages = append(trend.Languages, domain.LanguageTrend{Language: lang.Language, Change: change})
+		}
+	}
+	sort.SliceStable(trend.Languages, func(i, j int) bool {
+		return math.Abs(trend.Languages[i].Change) > math.Abs(trend.Languages[j].Change)
+	})
+
+	return trend
+}
+
+// userSnapshots loads the snapshots of username, or of everyone when
+// username is empty, oldest first
+func userSnapshots(history domain.FileRepository, username string) ([]domain.ProfileStats, error) {
+	snapshots, err := history.LoadSnapshots()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load snapshots: %w", err)
+	}
+
+	var matching []domain.ProfileStats
+	for _, snapshot := range snapshots {
+		if username == "" || strings.EqualFold(snapshot.Username, username) {
+			matching = append(matching, snapshot)
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		return matching[i].LastUpdated.Before(matching[j].LastUpdated)
+	})
+	return matching, nil
+}
+
+// HistoryUseCase lists the stored snapshots
+type HistoryUseCase struct {
+	history  domain.FileRepository
+	username string
+}
+
+// NewHistoryUseCase creates a new instance listing the snapshots of
+// username, or of every user when username is empty
+func NewHistoryUseCase(history domain.FileRepository, username string) *HistoryUseCase {
+	return &HistoryUseCase{
+		history:  history,
+		username: username,
+	}
+}
+
+// GetHistory returns the snapshots, oldest first
+func (uc *HistoryUseCase) GetHistory() ([]domain.ProfileStats, error) {
+	return userSnapshots(uc.history, uc.username)
+}
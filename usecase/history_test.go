@@ -0,0 +1,152 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"GitInsights/domain"
+	"GitInsights/usecase"
+)
+
+// MemoryHistory keeps snapshots in memory
+type MemoryHistory struct {
+	Snapshots []domain.ProfileStats
+}
+
+func (h *MemoryHistory) UpdateReadme(content string) error {
+	return nil
+}
+
+func (h *MemoryHistory) UpdateSection(section, content string) error {
+	return nil
+}
+
+func (h *MemoryHistory) SaveSnapshot(stats *domain.ProfileStats) error {
+	h.Snapshots = append(h.Snapshots, *stats)
+	return nil
+}
+
+func (h *MemoryHistory) LoadSnapshots() ([]domain.ProfileStats, error) {
+	return h.Snapshots, nil
+}
+
+func (h *MemoryHistory) WriteFile(path, content string) error {
+	return nil
+}
+
+func newHistoryMock() *MockGitHubRepository {
+	day := time.Now().AddDate(0, 0, -1)
+	return &MockGitHubRepository{
+		Username: "testuser",
+		UserProfile: &domain.UserProfile{
+			Username:  "testuser",
+			CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		LanguageStats: map[string]int{"Go": 600, "Python": 400},
+		Commits:       []domain.Commit{{SHA: "a", Date: day}, {SHA: "b", Date: day}},
+	}
+}
+
+func TestHistoryTrendSinceLastRun(t *testing.T) {
+	now := time.Now()
+	history := &MemoryHistory{Snapshots: []domain.ProfileStats{
+		{Username: "testuser", Weighting: "bytes", TotalCommits: 1, LastUpdated: now.AddDate(0, -2, 0),
+			Languages: []domain.LanguageStats{{Language: "Go", Percentage: 40}, {Language: "Python", Percentage: 60}}},
+		{Username: "testuser", Weighting: "bytes", TotalCommits: 0, LongestStreak: 0, LastUpdated: now.AddDate(0, 0, -3),
+			Languages: []domain.LanguageStats{{Language: "Go", Percentage: 55.8}, {Language: "Python", Percentage: 44.2}}},
+		{Username: "someone-else", TotalCommits: 999, LastUpdated: now.AddDate(0, 0, -1)},
+	}}
+
+	uc := usecase.NewProfileStatsUseCase(newHistoryMock(), 10, "", usecase.WithHistory(history, usecase.TrendSinceLastRun))
+	stats, err := uc.GetProfileStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	trend := stats.Trend
+	if trend == nil {
+		t.Fatal("Expected a trend against the previous run")
+	}
+	if !trend.Since.Equal(now.AddDate(0, 0, -3)) || trend.Commits != 2 || trend.LongestStreak != 1 {
+		t.Errorf("Expected +2 commits and +1 streak day since the last run of testuser, got: %+v", trend)
+	}
+	if len(trend.Languages) != 2 || trend.Languages[0].Language != "Go" ||
+		trend.Languages[0].Change < 4.19 || trend.Languages[0].Change > 4.21 {
+		t.Errorf("Expected Go +4.2%% first, got: %+v", trend.Languages)
+	}
+
+	// The run is only stored once its output is written
+	if len(history.Snapshots) != 3 {
+		t.Errorf("Expected this run not to be stored yet, got: %+v", history.Snapshots)
+	}
+	if err := uc.RecordSnapshot(stats); err != nil {
+		t.Fatalf("RecordSnapshot failed: %v", err)
+	}
+	if len(history.Snapshots) != 4 || history.Snapshots[3].TotalCommits != 2 {
+		t.Errorf("Expected this run to be stored, got: %+v", history.Snapshots)
+	}
+}
+
+func TestHistoryTrendSinceLastMonth(t *testing.T) {
+	now := time.Now()
+	history := &MemoryHistory{Snapshots: []domain.ProfileStats{
+		{Username: "testuser", Weighting: "bytes", TotalCommits: 1, LastUpdated: now.AddDate(0, -2, 0)},
+		{Username: "testuser", Weighting: "bytes", TotalCommits: 2, LastUpdated: now.AddDate(0, 0, -3)},
+	}}
+
+	uc := usecase.NewProfileStatsUseCase(newHistoryMock(), 10, "", usecase.WithHistory(history, usecase.TrendSinceLastMonth))
+	stats, err := uc.GetProfileStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if stats.Trend == nil || stats.Trend.Commits != 1 {
+		t.Errorf("Expected a trend against the snapshot from two months ago, got: %+v", stats.Trend)
+	}
+}
+
+func TestHistoryWithoutSnapshots(t *testing.T) {
+	history := &MemoryHistory{}
+	uc := usecase.NewProfileStatsUseCase(newHistoryMock(), 10, "", usecase.WithHistory(history, usecase.TrendSinceLastRun))
+	stats, err := uc.GetProfileStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if stats.Trend != nil {
+		t.Errorf("Expected no trend on the first run, got: %+v", stats.Trend)
+	}
+	if err := uc.RecordSnapshot(stats); err != nil {
+		t.Fatalf("RecordSnapshot failed: %v", err)
+	}
+
+	// The history lists the stored run
+	snapshots, err := usecase.NewHistoryUseCase(history, "TestUser").GetHistory()
+	if err != nil || len(snapshots) != 1 {
+		t.Errorf("Expected one snapshot in the history, got: %+v (%v)", snapshots, err)
+	}
+}
+
+func TestHistoryTrendNeedsSameSettings(t *testing.T) {
+	now := time.Now()
+	window, err := usecase.ParseTimeWindow("90d", "", now)
+	if err != nil {
+		t.Fatalf("ParseTimeWindow failed: %v", err)
+	}
+	// A rolling window taken a week ago still describes the same period
+	earlier, _ := usecase.ParseTimeWindow("90d", "", now.AddDate(0, 0, -7))
+
+	history := &MemoryHistory{Snapshots: []domain.ProfileStats{
+		{Username: "testuser", Weighting: "bytes", Window: earlier, TotalCommits: 1, LastUpdated: now.AddDate(0, 0, -7)},
+		{Username: "testuser", Weighting: "log-bytes", Window: window, TotalCommits: 50, LastUpdated: now.AddDate(0, 0, -2)},
+		{Username: "testuser", Weighting: "bytes", TotalCommits: 70, LastUpdated: now.AddDate(0, 0, -1)},
+	}}
+
+	uc := usecase.NewProfileStatsUseCase(newHistoryMock(), 10, "", usecase.WithTimeWindow(window), usecase.WithHistory(history, usecase.TrendSinceLastRun))
+	stats, err := uc.GetProfileStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if stats.Trend == nil || stats.Trend.Commits != 1 {
+		t.Errorf("Expected a trend against the snapshot with the same window and weighting, got: %+v", stats.Trend)
+	}
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"sort"
+	"strings"
+
+	"GitInsights/domain"
+)
+
+// evolutionLanguages is the number of languages the evolution names; the
+// rest are grouped into "Other"
+const evolutionLanguages = 5
+
+// calculateLanguageEvolution attributes languages to years through commits:
+// every commit counts once, split across its repository's languages by their
+// bytes. The languages used most over all years are named in every year.
+func (uc *ProfileStatsUseCase) calculateLanguageEvolution(repositories []domain.Repository, commits []domain.Commit) []domain.YearLanguages {
+	reposByName := make(map[string]domain.Repository, len(repositories))
+	for _, repo := range repositories {
+		reposByName[strings.ToLower(repo.FullName())] = repo
+	}
+
+	mixes := make(map[string]map[string]float64)
+	weights := make(map[int]map[string]float64)
+	commitsByYear := make(map[int]int)
+	overall := make(map[string]float64)
+	for _, commit := range commits {
+		name := strings.ToLower(commit.Repository)
+		repo, ok := reposByName[name]
+		if !ok {
+			continue
+		}
+		mix, ok := mixes[name]
+		if !ok {
+			mix = uc.languageMix(repo)
+			mixes[name] = mix
+		}
+		if len(mix) == 0 {
+			continue
+		}
+
+		year := commit.Date.Year()
+		if weights[year] == nil {
+			weights[year] = make(map[string]float64)
+		}
+		commitsByYear[year]++
+		for lang, share := range mix {
+			weights[year][lang] += share
+			overall[lang] += share
+		}
+	}
+	if len(weights) == 0 {
+		return nil
+	}
+
+	named := make([]string, 0, len(overall))
+	for lang := range overall {
+		named = append(named, lang)
+	}
+	sort.Slice(named, func(i, j int) bool {
+		if overall[named[i]] != overall[named[j]] {
+			return overall[named[i]] > overall[named[j]]
+		}
+		return named[i] < named[j]
+	})
+	hasOther := len(named) > evolutionLanguages
+	if hasOther {
+		named = named[:evolutionLanguages]
+	}
+
+	years := make([]int, 0, len(weights))
+	for year := range weights {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+
+	evolution := make([]domain.YearLanguages, 0, len(years))
+	for _, year := range years {
+		total := float64(commitsByYear[year])
+		languages := make([]domain.LanguageStats, 0, len(named)+1)
+		rest := total
+		for _, lang := range named {
+			languages = append(languages, domain.LanguageStats{Language: lang, Percentage: weights[year][lang] / total * 100})
+			rest -= weights[year][lang]
+		}
+		if hasOther {
+			languages = append(languages, domain.LanguageStats{Language: "Other", Percentage: max(rest, 0) / total * 100})
+		}
+		roundPercentages(languages, uc.precision)
+
+		evolution = append(evolution, domain.YearLanguages{
+			Year:      year,
+			Commits:   commitsByYear[year],
+			Languages: languages,
+		})
+	}
+	return evolution
+}
+
+// languageMix returns the share of each bucketed language in a repository,
+// leaving out excluded languages
+func (uc *ProfileStatsUseCase) languageMix(repo domain.Repository) map[string]float64 {
+	bytesByLanguage := make(map[string]int)
+	total := 0
+	for lang, bytes := range repo.Languages {
+		if bucket := uc.languageRules.bucket(lang); !uc.isExcluded(lang) && !uc.isExcluded(bucket) {
+			bytesByLanguage[bucket] += bytes
+			total += bytes
+		}
+	}
+
+	mix := make(map[string]float64, len(bytesByLanguage))
+	for lang, bytes := range bytesByLanguage {
+		if bytes > 0 {
+			mix[lang] = float64(bytes) / float64(total)
+		}
+	}
+	return mix
+}
@@ -0,0 +1,82 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"GitInsights/domain"
+	"GitInsights/usecase"
+)
+
+func languageEvolution(t *testing.T, excludeLanguages string) []domain.YearLanguages {
+	t.Helper()
+
+	commit := func(year int, repo string) domain.Commit {
+		return domain.Commit{Date: time.Date(year, 6, 1, 12, 0, 0, 0, time.UTC), Repository: repo}
+	}
+	mockRepo := &MockGitHubRepository{
+		Username: "testuser",
+		UserProfile: &domain.UserProfile{
+			Username:  "testuser",
+			CreatedAt: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Repositories: []domain.Repository{
+			{Owner: "testuser", Name: "scripts", Languages: map[string]int{"Python": 900, "Shell": 100}},
+			{Owner: "testuser", Name: "server", Languages: map[string]int{"Go": 1000}},
+		},
+		Commits: []domain.Commit{
+			commit(2019, "testuser/scripts"), commit(2019, "testuser/scripts"),
+			commit(2019, "TestUser/Scripts"), commit(2019, "testuser/scripts"),
+			commit(2023, "testuser/server"), commit(2023, "testuser/server"),
+			commit(2023, "testuser/server"), commit(2023, "testuser/scripts"),
+			commit(2023, "someone/else"), commit(2023, ""),
+		},
+	}
+
+	uc := usecase.NewProfileStatsUseCase(mockRepo, 10, excludeLanguages, usecase.WithLocation(time.UTC))
+	stats, err := uc.GetProfileStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	return stats.LanguageEvolution
+}
+
+func assertYearLanguages(t *testing.T, got domain.YearLanguages, year, commits int, languages map[string]float64) {
+	t.Helper()
+	if got.Year != year || got.Commits != commits {
+		t.Errorf("Expected %d with %d commits, got: %+v", year, commits, got)
+	}
+	if len(got.Languages) != len(languages) {
+		t.Errorf("Expected %d languages in %d, got: %+v", len(languages), year, got.Languages)
+	}
+	for _, lang := range got.Languages {
+		if lang.DisplayPercentage != languages[lang.Language] {
+			t.Errorf("Expected %s at %.1f%% in %d, got: %.1f%%", lang.Language, languages[lang.Language], year, lang.DisplayPercentage)
+		}
+	}
+}
+
+func TestLanguageEvolution(t *testing.T) {
+	evolution := languageEvolution(t, "")
+	if len(evolution) != 2 {
+		t.Fatalf("Expected 2019 and 2023, got: %+v", evolution)
+	}
+
+	// Python leads overall, so it comes first in every year
+	if evolution[0].Languages[0].Language != "Python" || evolution[1].Languages[0].Language != "Python" {
+		t.Errorf("Expected the same language order every year, got: %+v", evolution)
+	}
+	assertYearLanguages(t, evolution[0], 2019, 4, map[string]float64{"Python": 90, "Go": 0, "Shell": 10})
+	// Commits to unknown repositories are left out
+	assertYearLanguages(t, evolution[1], 2023, 4, map[string]float64{"Python": 22.5, "Go": 75, "Shell": 2.5})
+}
+
+func TestLanguageEvolutionExcludesLanguages(t *testing.T) {
+	evolution := languageEvolution(t, "shell")
+	if len(evolution) != 2 {
+		t.Fatalf("Expected 2019 and 2023, got: %+v", evolution)
+	}
+	assertYearLanguages(t, evolution[0], 2019, 4, map[string]float64{"Python": 100, "Go": 0})
+	assertYearLanguages(t, evolution[1], 2023, 4, map[string]float64{"Python": 25, "Go": 75})
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"strings"
+
+	"GitInsights/domain"
+)
+
+// languageRuleSet is the compiled, case-insensitive form of domain.LanguageRules
+type languageRuleSet struct {
+	groups  map[string]string // language -> group name
+	renames map[string]string // language or group -> display name
+	pinned  map[string]bool   // display names
+}
+
+// newLanguageRuleSet compiles rules; an empty rule set leaves languages as they are
+func newLanguageRuleSet(rules domain.LanguageRules) *languageRuleSet {
+	r := &languageRuleSet{
+		groups:  make(map[string]string),
+		renames: make(map[string]string),
+		pinned:  make(map[string]bool),
+	}
+	for _, group := range rules.Groups {
+		for _, lang := range group.Languages {
+			r.groups[strings.ToLower(lang)] = group.Name
+		}
+	}
+	for from, to := range rules.Renames {
+		r.renames[strings.ToLower(from)] = to
+	}
+	for _, lang := range rules.Pinned {
+		r.pinned[strings.ToLower(lang)] = true
+	}
+	return r
+}
+
+// bucket returns the name a language is reported under
+func (r *languageRuleSet) bucket(language string) string {
+	name := language
+	if group, ok := r.groups[strings.ToLower(name)]; ok {
+		name = group
+	}
+	if renamed, ok := r.renames[strings.ToLower(name)]; ok {
+		name = renamed
+	}
+	return name
+}
+
+// apply merges the sizes and weights of languages that share a bucket
+func (r *languageRuleSet) apply(languages map[string]languageTotal) map[string]languageTotal {
+	buckets := make(map[string]languageTotal, len(languages))
+	for lang, total := range languages {
+		bucket := buckets[r.bucket(lang)]
+		bucket.bytes += total.bytes
+		bucket.weight += total.weight
+		buckets[r.bucket(lang)] = bucket
+	}
+	return buckets
+}
+
+// isPinned reports whether a bucket must always be shown
+func (r *languageRuleSet) isPinned(name string) bool {
+	return r.pinned[strings.ToLower(name)]
+}
@@ -0,0 +1,115 @@
+package usecase_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"GitInsights/domain"
+	"GitInsights/usecase"
+)
+
+func languageStatsWithRules(t *testing.T, languages map[string]int, maxVisible int, exclude string, rules domain.LanguageRules) []domain.LanguageStats {
+	t.Helper()
+
+	mockRepo := &MockGitHubRepository{
+		Username: "testuser",
+		UserProfile: &domain.UserProfile{
+			Username:  "testuser",
+			CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		LanguageStats: languages,
+	}
+
+	uc := usecase.NewProfileStatsUseCase(mockRepo, maxVisible, exclude, usecase.WithLanguageRules(rules))
+	stats, err := uc.GetProfileStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	return stats.Languages
+}
+
+func assertLanguage(t *testing.T, lang domain.LanguageStats, name string, bytes int, percentage float64) {
+	t.Helper()
+	if lang.Language != name || lang.Bytes != bytes || math.Abs(lang.Percentage-percentage) > 0.01 {
+		t.Errorf("Expected %s with %d bytes (%.2f%%), got: %+v", name, bytes, percentage, lang)
+	}
+}
+
+func TestLanguageGroupsRecomputePercentages(t *testing.T) {
+	languages := languageStatsWithRules(t, map[string]int{
+		"Go":               4000,
+		"SCSS":             1500,
+		"CSS":              1000,
+		"less":             500,
+		"Python":           2000,
+		"Jupyter Notebook": 1000,
+	}, 10, "", domain.LanguageRules{
+		Groups: []domain.LanguageGroup{
+			{Name: "Styles", Languages: []string{"SCSS", "CSS", "Less"}},
+			{Name: "Python", Languages: []string{"Jupyter Notebook"}},
+		},
+	})
+
+	if len(languages) != 3 {
+		t.Fatalf("Expected 3 buckets after merging, got: %+v", languages)
+	}
+	assertLanguage(t, languages[0], "Go", 4000, 40)
+	assertLanguage(t, languages[1], "Python", 3000, 30)
+	assertLanguage(t, languages[2], "Styles", 3000, 30)
+}
+
+func TestLanguageRenamesApplyToGroupsAndLanguages(t *testing.T) {
+	languages := languageStatsWithRules(t, map[string]int{
+		"Vim Script": 600,
+		"CSS":        300,
+		"SCSS":       100,
+	}, 10, "", domain.LanguageRules{
+		Groups:  []domain.LanguageGroup{{Name: "Styles", Languages: []string{"CSS", "SCSS"}}},
+		Renames: map[string]string{"vim script": "Vim", "Styles": "Stylesheets"},
+	})
+
+	if len(languages) != 2 {
+		t.Fatalf("Expected 2 buckets, got: %+v", languages)
+	}
+	assertLanguage(t, languages[0], "Vim", 600, 60)
+	assertLanguage(t, languages[1], "Stylesheets", 400, 40)
+}
+
+func TestPinnedLanguagesShowOutsideTopN(t *testing.T) {
+	languages := languageStatsWithRules(t, map[string]int{
+		"Go":         5000,
+		"TypeScript": 3000,
+		"Shell":      1000,
+		"Rust":       600,
+		"Makefile":   400,
+	}, 2, "", domain.LanguageRules{
+		Pinned: []string{"rust"},
+	})
+
+	if len(languages) != 4 {
+		t.Fatalf("Expected top 2, the pinned language and Other, got: %+v", languages)
+	}
+	assertLanguage(t, languages[0], "Go", 5000, 50)
+	assertLanguage(t, languages[1], "TypeScript", 3000, 30)
+	assertLanguage(t, languages[2], "Rust", 600, 6)
+	assertLanguage(t, languages[3], "Other", 1400, 14)
+}
+
+func TestExcludingAGroupRecomputesPercentages(t *testing.T) {
+	languages := languageStatsWithRules(t, map[string]int{
+		"Go":   3000,
+		"HTML": 500,
+		"CSS":  500,
+		"Java": 1000,
+	}, 10, "styles", domain.LanguageRules{
+		Groups: []domain.LanguageGroup{{Name: "Styles", Languages: []string{"HTML", "CSS"}}},
+	})
+
+	if len(languages) != 2 {
+		t.Fatalf("Expected the whole group to be excluded, got: %+v", languages)
+	}
+	assertLanguage(t, languages[0], "Go", 3000, 75)
+	assertLanguage(t, languages[1], "Java", 1000, 25)
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"GitInsights/domain"
+)
+
+// LanguageWeighting decides how much each language counts towards the
+// displayed percentages. Byte counts are always reported as they are.
+type LanguageWeighting interface {
+	Weigh(repositories []domain.Repository, commits []domain.Commit, now time.Time) map[string]float64
+}
+
+// DefaultRecencyHalfLife is how long it takes a repository's weight to halve
+// with RecencyWeighting
+const DefaultRecencyHalfLife = 365 * 24 * time.Hour
+
+// BytesWeighting weighs languages by their total size
+type BytesWeighting struct{}
+
+// Weigh sums the bytes of every repository
+func (BytesWeighting) Weigh(repositories []domain.Repository, _ []domain.Commit, _ time.Time) map[string]float64 {
+	return weighRepositories(repositories, func(_ domain.Repository, bytes int) float64 {
+		return float64(bytes)
+	})
+}
+
+// RepoCountWeighting weighs languages by the number of repositories using them
+type RepoCountWeighting struct{}
+
+// Weigh counts one for every repository containing the language
+func (RepoCountWeighting) Weigh(repositories []domain.Repository, _ []domain.Commit, _ time.Time) map[string]float64 {
+	return weighRepositories(repositories, func(_ domain.Repository, bytes int) float64 {
+		if bytes <= 0 {
+			return 0
+		}
+		return 1
+	})
+}
+
+// LogBytesWeighting weighs languages by the logarithm of their size in each
+// repository, so a single huge repository doesn't dominate the profile
+type LogBytesWeighting struct{}
+
+// Weigh sums log(1 + bytes) over every repository
+func (LogBytesWeighting) Weigh(repositories []domain.Repository, _ []domain.Commit, _ time.Time) map[string]float64 {
+	return weighRepositories(repositories, func(_ domain.Repository, bytes int) float64 {
+		return math.Log1p(float64(bytes))
+	})
+}
+
+// CommitWeighting weighs each repository's languages by its share of the
+// user's commits. Sources that don't know the repository of a commit fall
+// back to plain byte counts.
+type CommitWeighting struct{}
+
+// Weigh multiplies every repository's bytes by its number of commits
+func (CommitWeighting) Weigh(repositories []domain.Repository, commits []domain.Commit, now time.Time) map[string]float64 {
+	commitsByRepo := make(map[string]int)
+	for _, commit := range commits {
+		if commit.Repository != "" {
+			commitsByRepo[strings.ToLower(commit.Repository)]++
+		}
+	}
+
+	attributed := false
+	for _, repo := range repositories {
+		if commitsByRepo[strings.ToLower(repo.FullName())] > 0 {
+			attributed = true
+			break
+		}
+	}
+	if !attributed {
+		return BytesWeighting{}.Weigh(repositories, commits, now)
+	}
+
+	// Weigh by the share of commits so the weights stay in the byte range
+	total := 0
+	for _, count := range commitsByRepo {
+		total += count
+	}
+	return weighRepositories(repositories, func(repo domain.Repository, bytes int) float64 {
+		share := float64(commitsByRepo[strings.ToLower(repo.FullName())]) / float64(total)
+		return float64(bytes) * share
+	})
+}
+
+// RecencyWeighting weighs languages by size, decaying each repository by the
+// time since its last push. Repositories without a push date aren't decayed.
+type RecencyWeighting struct {
+	HalfLife time.Duration
+}
+
+// Weigh halves a repository's bytes for every HalfLife since its last push
+func (w RecencyWeighting) Weigh(repositories []domain.Repository, _ []domain.Commit, now time.Time) map[string]float64 {
+	halfLife := w.HalfLife
+	if halfLife <= 0 {
+		halfLife = DefaultRecencyHalfLife
+	}
+
+	return weighRepositories(repositories, func(repo domain.Repository, bytes int) float64 {
+		if repo.PushedAt.IsZero() || !repo.PushedAt.Before(now) {
+			return float64(bytes)
+		}
+		age := now.Sub(repo.PushedAt)
+		return float64(bytes) * math.Pow(0.5, float64(age)/float64(halfLife))
+	})
+}
+
+// weighRepositories sums the weight of every language of every repository
+func weighRepositories(repositories []domain.Repository, weigh func(repo domain.Repository, bytes int) float64) map[string]float64 {
+	weights := make(map[string]float64)
+	for _, repo := range repositories {
+		for lang, bytes := range repo.Languages {
+			weights[lang] += weigh(repo, bytes)
+		}
+	}
+	return weights
+}
+
+// LanguageWeightingNames lists the strategies accepted by ParseLanguageWeighting
+var LanguageWeightingNames = []string{"bytes", "repo-count", "log-bytes", "commit-weighted", "recency-weighted"}
+
+// languageWeightingName returns the name ParseLanguageWeighting accepts for
+// weighting, or its type for other strategies
+func languageWeightingName(weighting LanguageWeighting) string {
+	switch weighting.(type) {
+	case BytesWeighting:
+		return "bytes"
+	case RepoCountWeighting:
+		return "repo-count"
+	case LogBytesWeighting:
+		return "log-bytes"
+	case CommitWeighting:
+		return "commit-weighted"
+	case RecencyWeighting:
+		return "recency-weighted"
+	}
+	return fmt.Sprintf("%T", weighting)
+}
+
+// ParseLanguageWeighting returns the strategy with the given name
+func ParseLanguageWeighting(name string) (LanguageWeighting, error) {
+	switch name {
+	case "bytes":
+		return BytesWeighting{}, nil
+	case "repo-count":
+		return RepoCountWeighting{}, nil
+	case "log-bytes":
+		return LogBytesWeighting{}, nil
+	case "commit-weighted":
+		return CommitWeighting{}, nil
+	case "recency-weighted":
+		return RecencyWeighting{HalfLife: DefaultRecencyHalfLife}, nil
+	}
+	return nil, fmt.Errorf("unknown language weighting %q (expected one of: %s)", name, strings.Join(LanguageWeightingNames, ", "))
+}
@@ -0,0 +1,135 @@
+package usecase_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"GitInsights/domain"
+	"GitInsights/usecase"
+)
+
+// weightingRepositories are a large, stale C repository and two small, active
+// Go and TypeScript repositories
+func weightingRepositories() []domain.Repository {
+	now := time.Now()
+	return []domain.Repository{
+		{Owner: "testuser", Name: "kernel", Languages: map[string]int{"C": 8000}, PushedAt: now.AddDate(-3, 0, 0)},
+		{Owner: "testuser", Name: "api", Languages: map[string]int{"Go": 1000, "Shell": 100}, PushedAt: now},
+		{Owner: "testuser", Name: "web", Languages: map[string]int{"TypeScript": 900, "Shell": 100}, PushedAt: now},
+	}
+}
+
+func languageStatsWithWeighting(t *testing.T, weighting usecase.LanguageWeighting, commits []domain.Commit) map[string]domain.LanguageStats {
+	t.Helper()
+
+	mockRepo := &MockGitHubRepository{
+		Username: "testuser",
+		UserProfile: &domain.UserProfile{
+			Username:  "testuser",
+			CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Repositories: weightingRepositories(),
+		Commits:      commits,
+	}
+
+	uc := usecase.NewProfileStatsUseCase(mockRepo, 10, "", usecase.WithLanguageWeighting(weighting))
+	stats, err := uc.GetProfileStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	byName := make(map[string]domain.LanguageStats)
+	for _, lang := range stats.Languages {
+		byName[lang.Language] = lang
+	}
+	if stats.TotalBytes != 10100 || byName["Go"].Bytes != 1000 {
+		t.Errorf("Expected byte counts to be unaffected by the weighting, got: %+v", stats.Languages)
+	}
+	return byName
+}
+
+func assertPercentage(t *testing.T, languages map[string]domain.LanguageStats, name string, percentage float64) {
+	t.Helper()
+	if math.Abs(languages[name].Percentage-percentage) > 0.01 {
+		t.Errorf("Expected %s at %.2f%%, got: %.2f%%", name, percentage, languages[name].Percentage)
+	}
+}
+
+func TestBytesWeighting(t *testing.T) {
+	languages := languageStatsWithWeighting(t, usecase.BytesWeighting{}, nil)
+	assertPercentage(t, languages, "C", 8000.0/10100*100)
+	assertPercentage(t, languages, "Shell", 200.0/10100*100)
+}
+
+func TestRepoCountWeighting(t *testing.T) {
+	languages := languageStatsWithWeighting(t, usecase.RepoCountWeighting{}, nil)
+
+	// Shell appears in two repositories, every other language in one
+	assertPercentage(t, languages, "Shell", 40)
+	assertPercentage(t, languages, "C", 20)
+	assertPercentage(t, languages, "Go", 20)
+}
+
+func TestLogBytesWeighting(t *testing.T) {
+	languages := languageStatsWithWeighting(t, usecase.LogBytesWeighting{}, nil)
+
+	// The huge C repository no longer dominates
+	if languages["C"].Percentage >= 30 {
+		t.Errorf("Expected C below 30%% with log weighting, got: %.2f%%", languages["C"].Percentage)
+	}
+	if languages["Shell"].Percentage <= languages["Go"].Percentage {
+		t.Errorf("Expected Shell from two repositories to outweigh Go, got: %+v", languages)
+	}
+}
+
+func TestCommitWeighting(t *testing.T) {
+	day := time.Date(2023, 11, 13, 9, 0, 0, 0, time.UTC)
+	commits := []domain.Commit{
+		{SHA: "a", Date: day, Repository: "testuser/api"},
+		{SHA: "b", Date: day, Repository: "testuser/api"},
+		{SHA: "c", Date: day, Repository: "TestUser/API"},
+		{SHA: "d", Date: day, Repository: "testuser/web"},
+	}
+	languages := languageStatsWithWeighting(t, usecase.CommitWeighting{}, commits)
+
+	// The C repository has no commits, so it drops out of the percentages
+	if _, ok := languages["C"]; ok {
+		t.Errorf("Expected C without commits to be left out, got: %+v", languages["C"])
+	}
+	// Go: 1000*3, TypeScript: 900*1, Shell: 100*3 + 100*1
+	assertPercentage(t, languages, "Go", 3000.0/4300*100)
+	assertPercentage(t, languages, "TypeScript", 900.0/4300*100)
+}
+
+func TestCommitWeightingFallsBackToBytes(t *testing.T) {
+	day := time.Date(2023, 11, 13, 9, 0, 0, 0, time.UTC)
+	languages := languageStatsWithWeighting(t, usecase.CommitWeighting{}, []domain.Commit{{Date: day, DateOnly: true}})
+	assertPercentage(t, languages, "C", 8000.0/10100*100)
+}
+
+func TestRecencyWeighting(t *testing.T) {
+	languages := languageStatsWithWeighting(t, usecase.RecencyWeighting{HalfLife: 365 * 24 * time.Hour}, nil)
+
+	// Three years without a push cut the C repository to about an eighth
+	c := 8000 * math.Pow(0.5, 3)
+	total := c + 2100
+	if math.Abs(languages["C"].Percentage-c/total*100) > 0.5 {
+		t.Errorf("Expected C around %.2f%%, got: %.2f%%", c/total*100, languages["C"].Percentage)
+	}
+	if languages["Go"].Percentage <= languages["C"].Percentage {
+		t.Errorf("Expected the active Go repository to outweigh the stale C one, got: %+v", languages)
+	}
+}
+
+func TestParseLanguageWeighting(t *testing.T) {
+	for _, name := range usecase.LanguageWeightingNames {
+		if _, err := usecase.ParseLanguageWeighting(name); err != nil {
+			t.Errorf("Expected %q to be accepted, got: %v", name, err)
+		}
+	}
+	if _, err := usecase.ParseLanguageWeighting("lines"); err == nil {
+		t.Error("Expected an error for an unknown weighting, got nil")
+	}
+}
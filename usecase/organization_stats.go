@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"GitInsights/domain"
+)
+
+// OrganizationStatsUseCase orchestrates business logic for organization and
+// team statistics. The aggregate is calculated exactly like a profile, from
+// the languages of every repository and the commits of every member.
+type OrganizationStatsUseCase struct {
+	orgRepo domain.OrganizationRepository
+	profile *ProfileStatsUseCase
+	org     string
+	team    string
+}
+
+// NewOrganizationStatsUseCase creates a new instance for org, or for one of
+// its teams when team is set
+func NewOrganizationStatsUseCase(orgRepo domain.OrganizationRepository, org, team string, maxVisibleLanguages int, excludeLanguagesStr string, opts ...Option) *OrganizationStatsUseCase {
+	return &OrganizationStatsUseCase{
+		orgRepo: orgRepo,
+		profile: NewProfileStatsUseCase(nil, maxVisibleLanguages, excludeLanguagesStr, opts...),
+		org:     org,
+		team:    team,
+	}
+}
+
+// GetOrganizationStats retrieves and calculates the aggregate statistics and
+// the per-member breakdown
+func (uc *OrganizationStatsUseCase) GetOrganizationStats(ctx context.Context) (*domain.OrganizationStats, error) {
+	// Get organization profile for its age
+	orgProfile, err := uc.orgRepo.GetOrganizationProfile(ctx, uc.org)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization profile: %w", err)
+	}
+
+	members, err := uc.orgRepo.GetOrganizationMembers(ctx, uc.org, uc.team)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members: %w", err)
+	}
+
+	repositories, err := uc.orgRepo.GetOrganizationRepositories(ctx, uc.org, uc.team)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repositories: %w", err)
+	}
+
+	commits, err := uc.orgRepo.GetOrganizationCommits(ctx, repositories, members)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits: %w", err)
+	}
+
+	repositories, commits = uc.profile.applyWindow(repositories, commits)
+
+	name := orgProfile.Username
+	if uc.team != "" {
+		name += "/" + uc.team
+	}
+
+	return &domain.OrganizationStats{
+		Summary:      uc.profile.buildStats(name, orgProfile.CreatedAt, repositories, commits),
+		Repositories: len(repositories),
+		Members:      uc.rankMembers(members, repositories, commits),
+	}, nil
+}
+
+// rankMembers summarizes every member's commits, most active first. Members
+// without commits are kept at the end so the table shows the whole team.
+func (uc *OrganizationStatsUseCase) rankMembers(members []string, repositories []domain.Repository, commits []domain.Commit) []domain.MemberStats {
+	reposByName := make(map[string]domain.Repository, len(repositories))
+	for _, repo := range repositories {
+		reposByName[repo.FullName()] = repo
+	}
+
+	// Resolve commit authors to member logins regardless of case
+	memberIndex := make(map[string]int, len(members))
+	stats := make([]domain.MemberStats, len(members))
+	for i, member := range members {
+		memberIndex[strings.ToLower(member)] = i
+		stats[i].Login = member
+	}
+
+	repoCommits := make(map[string]int)
+	memberRepoCommits := make([]map[string]int, len(members))
+	for _, commit := range commits {
+		i, ok := memberIndex[strings.ToLower(commit.Author)]
+		if !ok {
+			continue
+		}
+
+		stats[i].Commits++
+		if commit.Date.After(stats[i].LastCommit) {
+			stats[i].LastCommit = commit.Date
+		}
+
+		if memberRepoCommits[i] == nil {
+			memberRepoCommits[i] = make(map[string]int)
+		}
+		memberRepoCommits[i][commit.Repository]++
+		repoCommits[commit.Repository]++
+	}
+
+	for i := range stats {
+		stats[i].Repositories = len(memberRepoCommits[i])
+
+		// Each repository's languages count in proportion to the member's
+		// share of its commits
+		weighted := make(map[string]float64)
+		for repoName, count := range memberRepoCommits[i] {
+			share := float64(count) / float64(repoCommits[repoName])
+			for lang, bytes := range reposByName[repoName].Languages {
+				weighted[lang] += float64(bytes) * share
+			}
+		}
+
+		languageMap := make(map[string]int, len(weighted))
+		for lang, bytes := range weighted {
+			languageMap[lang] = int(math.Round(bytes))
+		}
+		stats[i].Languages = uc.profile.processLanguages(languageMap, weighted)
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Commits != stats[j].Commits {
+			return stats[i].Commits > stats[j].Commits
+		}
+		return strings.ToLower(stats[i].Login) < strings.ToLower(stats[j].Login)
+	})
+
+	return stats
+}
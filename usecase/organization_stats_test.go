@@ -0,0 +1,148 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"GitInsights/domain"
+	"GitInsights/usecase"
+)
+
+// MockOrganizationRepository for testing
+type MockOrganizationRepository struct {
+	Profile      *domain.UserProfile
+	Members      []string
+	Repositories []domain.Repository
+	Commits      []domain.Commit
+	Err          error
+
+	RequestedTeam string
+}
+
+func (m *MockOrganizationRepository) GetOrganizationProfile(ctx context.Context, org string) (*domain.UserProfile, error) {
+	return m.Profile, m.Err
+}
+
+func (m *MockOrganizationRepository) GetOrganizationMembers(ctx context.Context, org, team string) ([]string, error) {
+	m.RequestedTeam = team
+	return m.Members, m.Err
+}
+
+func (m *MockOrganizationRepository) GetOrganizationRepositories(ctx context.Context, org, team string) ([]domain.Repository, error) {
+	return m.Repositories, m.Err
+}
+
+func (m *MockOrganizationRepository) GetOrganizationCommits(ctx context.Context, repositories []domain.Repository, members []string) ([]domain.Commit, error) {
+	return m.Commits, m.Err
+}
+
+func newMockOrganization() *MockOrganizationRepository {
+	day := func(d int) time.Time { return time.Date(2023, 11, d, 10, 0, 0, 0, time.UTC) }
+	return &MockOrganizationRepository{
+		Profile: &domain.UserProfile{
+			Username:  "acme",
+			CreatedAt: time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Members: []string{"alice", "Bob", "carol"},
+		Repositories: []domain.Repository{
+			{Owner: "acme", Name: "api", Languages: map[string]int{"Go": 9000, "Shell": 1000}},
+			{Owner: "acme", Name: "web", Languages: map[string]int{"TypeScript": 4000}},
+		},
+		Commits: []domain.Commit{
+			{Repository: "acme/api", Author: "alice", Date: day(13)},
+			{Repository: "acme/api", Author: "alice", Date: day(14)},
+			{Repository: "acme/api", Author: "alice", Date: day(15)},
+			{Repository: "acme/api", Author: "bob", Date: day(13)},
+			{Repository: "acme/web", Author: "bob", Date: day(16)},
+		},
+	}
+}
+
+func TestGetOrganizationStats(t *testing.T) {
+	repo := newMockOrganization()
+	uc := usecase.NewOrganizationStatsUseCase(repo, "acme", "", 10, "")
+
+	stats, err := uc.GetOrganizationStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if stats.Summary.Username != "acme" {
+		t.Errorf("Expected summary for 'acme', got: %s", stats.Summary.Username)
+	}
+	if stats.Summary.TotalBytes != 14000 {
+		t.Errorf("Expected languages of every repository (14000 bytes), got: %d", stats.Summary.TotalBytes)
+	}
+	if stats.Summary.LongestStreak != 4 {
+		t.Errorf("Expected the members' commits to form a 4 day streak, got: %d", stats.Summary.LongestStreak)
+	}
+	if stats.Repositories != 2 {
+		t.Errorf("Expected 2 repositories, got: %d", stats.Repositories)
+	}
+
+	if len(stats.Members) != 3 {
+		t.Fatalf("Expected every member in the breakdown, got: %d", len(stats.Members))
+	}
+	alice, bob, carol := stats.Members[0], stats.Members[1], stats.Members[2]
+	if alice.Login != "alice" || alice.Commits != 3 || alice.Repositories != 1 {
+		t.Errorf("Expected alice first with 3 commits in 1 repository, got: %+v", alice)
+	}
+	if bob.Login != "Bob" || bob.Commits != 2 || bob.Repositories != 2 {
+		t.Errorf("Expected Bob second with 2 commits in 2 repositories, got: %+v", bob)
+	}
+	if !bob.LastCommit.Equal(time.Date(2023, 11, 16, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected Bob's last commit on November 16, got: %s", bob.LastCommit)
+	}
+	if carol.Login != "carol" || carol.Commits != 0 || len(carol.Languages) != 0 {
+		t.Errorf("Expected carol last without commits or languages, got: %+v", carol)
+	}
+}
+
+func TestMemberLanguagesFollowCommitShare(t *testing.T) {
+	repo := newMockOrganization()
+	uc := usecase.NewOrganizationStatsUseCase(repo, "acme", "", 10, "")
+
+	stats, err := uc.GetOrganizationStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Bob made a quarter of the api commits (2500 bytes) and all web commits
+	// (4000 bytes), so TypeScript leads his languages
+	bob := stats.Members[1]
+	if len(bob.Languages) != 3 || bob.Languages[0].Language != "TypeScript" {
+		t.Fatalf("Expected TypeScript first among 3 languages, got: %+v", bob.Languages)
+	}
+	if bob.Languages[0].Bytes != 4000 || bob.Languages[1].Bytes != 2250 {
+		t.Errorf("Expected TypeScript=4000 and Go=2250, got: %+v", bob.Languages)
+	}
+}
+
+func TestTeamStatsName(t *testing.T) {
+	repo := newMockOrganization()
+	uc := usecase.NewOrganizationStatsUseCase(repo, "acme", "platform", 10, "")
+
+	stats, err := uc.GetOrganizationStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if repo.RequestedTeam != "platform" {
+		t.Errorf("Expected members of team 'platform', got: %q", repo.RequestedTeam)
+	}
+	if stats.Summary.Username != "acme/platform" {
+		t.Errorf("Expected summary for 'acme/platform', got: %s", stats.Summary.Username)
+	}
+}
+
+func TestGetOrganizationStatsError(t *testing.T) {
+	repo := newMockOrganization()
+	repo.Err = errors.New("not found")
+	uc := usecase.NewOrganizationStatsUseCase(repo, "acme", "", 10, "")
+
+	if _, err := uc.GetOrganizationStats(context.Background()); err == nil {
+		t.Error("Expected an error")
+	}
+}
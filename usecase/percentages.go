@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+
+	"GitInsights/domain"
+)
+
+// DefaultPercentagePrecision is the number of decimals shown by default
+const DefaultPercentagePrecision = 1
+
+// roundPercentages sets the DisplayPercentage of every language with the
+// largest remainder method: each percentage is rounded down to precision
+// decimals, and the units still missing from 100 go to the languages that
+// lost the most, so the displayed values always add up to exactly 100.
+func roundPercentages(languages []domain.LanguageStats, precision int) {
+	if len(languages) == 0 {
+		return
+	}
+
+	scale := math.Pow(10, float64(precision))
+	remainders := make([]float64, len(languages))
+	assigned := 0
+	for i, lang := range languages {
+		scaled := lang.Percentage * scale
+		units := math.Floor(scaled)
+		remainders[i] = scaled - units
+		languages[i].DisplayPercentage = units
+		assigned += int(units)
+	}
+
+	// Hand out the missing units, largest remainder first; ties go to the
+	// language listed first
+	order := make([]int, len(languages))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+
+	missing := int(math.Round(100*scale)) - assigned
+	for i := 0; i < missing && i < len(order); i++ {
+		languages[order[i]].DisplayPercentage++
+	}
+
+	for i := range languages {
+		languages[i].DisplayPercentage /= scale
+	}
+}
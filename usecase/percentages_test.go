@@ -0,0 +1,79 @@
+package usecase_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"GitInsights/domain"
+	"GitInsights/usecase"
+)
+
+func displayPercentages(t *testing.T, languages map[string]int, maxVisible int, opts ...usecase.Option) []domain.LanguageStats {
+	t.Helper()
+
+	mockRepo := &MockGitHubRepository{
+		Username: "testuser",
+		UserProfile: &domain.UserProfile{
+			Username:  "testuser",
+			CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		LanguageStats: languages,
+	}
+
+	uc := usecase.NewProfileStatsUseCase(mockRepo, maxVisible, "", opts...)
+	stats, err := uc.GetProfileStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	return stats.Languages
+}
+
+func sumDisplayPercentages(languages []domain.LanguageStats) float64 {
+	total := 0.0
+	for _, lang := range languages {
+		total += lang.DisplayPercentage
+	}
+	return total
+}
+
+func TestDisplayPercentagesSumTo100(t *testing.T) {
+	// Three equal thirds round to 33.3 each on their own
+	languages := displayPercentages(t, map[string]int{"Go": 100, "Rust": 100, "Zig": 100}, 10)
+
+	if math.Abs(sumDisplayPercentages(languages)-100) > 1e-9 {
+		t.Errorf("Expected display percentages to sum to 100, got: %+v", languages)
+	}
+	// The unit left over goes to the first language
+	if languages[0].DisplayPercentage != 33.4 || languages[1].DisplayPercentage != 33.3 {
+		t.Errorf("Expected 33.4, 33.3, 33.3, got: %+v", languages)
+	}
+}
+
+func TestDisplayPercentagesLargestRemainder(t *testing.T) {
+	// 61.84%, 25.97%, 12.19% round down to 61, 25 and 12; the two missing
+	// units go to the largest remainders, Rust's .97 and Go's .84
+	languages := displayPercentages(t, map[string]int{"Go": 6184, "Rust": 2597, "C": 1219}, 10,
+		usecase.WithPercentagePrecision(0))
+
+	want := map[string]float64{"Go": 62, "Rust": 26, "C": 12}
+	for _, lang := range languages {
+		if lang.DisplayPercentage != want[lang.Language] {
+			t.Errorf("Expected %s at %.0f%%, got: %v", lang.Language, want[lang.Language], lang.DisplayPercentage)
+		}
+	}
+}
+
+func TestDisplayPercentagesIncludeOther(t *testing.T) {
+	languages := displayPercentages(t, map[string]int{
+		"Go": 3333, "Rust": 3333, "Zig": 1111, "C": 1111, "Lua": 1112,
+	}, 2, usecase.WithPercentagePrecision(2))
+
+	if len(languages) != 3 || languages[2].Language != "Other" {
+		t.Fatalf("Expected two languages and Other, got: %+v", languages)
+	}
+	if math.Abs(sumDisplayPercentages(languages)-100) > 1e-9 {
+		t.Errorf("Expected display percentages to sum to 100, got: %+v", languages)
+	}
+}
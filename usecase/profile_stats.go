@@ -15,10 +15,70 @@ type ProfileStatsUseCase struct {
 	githubRepo          domain.GitHubRepository
 	maxVisibleLanguages int
 	excludeLanguages    []string
+	location            *time.Location
+	username            string
+	languageRules       *languageRuleSet
+	weighting           LanguageWeighting
+	precision           int
+	window              domain.TimeWindow
+	history             domain.FileRepository
+	trendBaseline       TrendBaseline
+}
+
+// Option configures optional behaviour of ProfileStatsUseCase
+type Option func(*ProfileStatsUseCase)
+
+// WithLocation makes every time-bucketed metric (peak hours, weekdays, streaks)
+// use the calendar of the given location. A nil location keeps each commit's
+// own offset as reported by the data source.
+func WithLocation(location *time.Location) Option {
+	return func(uc *ProfileStatsUseCase) {
+		uc.location = location
+	}
+}
+
+// WithUsername analyzes the given login instead of the account the
+// repository's credentials belong to
+func WithUsername(username string) Option {
+	return func(uc *ProfileStatsUseCase) {
+		uc.username = username
+	}
+}
+
+// WithLanguageRules groups, renames and pins languages before the top N
+// languages are selected
+func WithLanguageRules(rules domain.LanguageRules) Option {
+	return func(uc *ProfileStatsUseCase) {
+		uc.languageRules = newLanguageRuleSet(rules)
+	}
+}
+
+// WithLanguageWeighting changes how much each language counts towards the
+// displayed percentages; the default weighs languages by their size
+func WithLanguageWeighting(weighting LanguageWeighting) Option {
+	return func(uc *ProfileStatsUseCase) {
+		uc.weighting = weighting
+	}
+}
+
+// WithPercentagePrecision sets the number of decimals display percentages
+// are rounded to
+func WithPercentagePrecision(precision int) Option {
+	return func(uc *ProfileStatsUseCase) {
+		uc.precision = precision
+	}
+}
+
+// WithTimeWindow limits commits and languages to the given period. Only
+// repositories pushed to since the window started count towards languages.
+func WithTimeWindow(window domain.TimeWindow) Option {
+	return func(uc *ProfileStatsUseCase) {
+		uc.window = window
+	}
 }
 
 // NewProfileStatsUseCase creates a new instance
-func NewProfileStatsUseCase(githubRepo domain.GitHubRepository, maxVisibleLanguages int, excludeLanguagesStr string) *ProfileStatsUseCase {
+func NewProfileStatsUseCase(githubRepo domain.GitHubRepository, maxVisibleLanguages int, excludeLanguagesStr string, opts ...Option) *ProfileStatsUseCase {
 	var excludeLanguages []string
 	if excludeLanguagesStr != "" {
 		// Split by comma and trim spaces, convert to lowercase for case-insensitive matching
@@ -30,50 +90,131 @@ func NewProfileStatsUseCase(githubRepo domain.GitHubRepository, maxVisibleLangua
 			}
 		}
 	}
-	return &ProfileStatsUseCase{
+	uc := &ProfileStatsUseCase{
 		githubRepo:          githubRepo,
 		maxVisibleLanguages: maxVisibleLanguages,
 		excludeLanguages:    excludeLanguages,
+		languageRules:       newLanguageRuleSet(domain.LanguageRules{}),
+		weighting:           BytesWeighting{},
+		precision:           DefaultPercentagePrecision,
 	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
 }
 
 // GetProfileStats retrieves and calculates all profile statistics
 func (uc *ProfileStatsUseCase) GetProfileStats(ctx context.Context) (*domain.ProfileStats, error) {
-	// Get username
-	username, err := uc.githubRepo.GetUsername(ctx)
+	username, err := uc.resolveUsername(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get username: %w", err)
+		return nil, err
 	}
 
 	// Get user profile for account age
-	userProfile, err := uc.githubRepo.GetUserProfile(ctx)
+	userProfile, err := uc.githubRepo.GetUserProfile(ctx, uc.username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user profile: %w", err)
 	}
 
-	// Calculate account age
-	accountAge := uc.calculateAccountAge(userProfile.CreatedAt)
+	// Get repositories and their languages
+	repositories, err := uc.githubRepo.GetRepositories(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repositories: %w", err)
+	}
 
-	// Get language statistics
-	languageMap, err := uc.githubRepo.GetLanguageStats(ctx, username)
+	// Get commits
+	commits, err := uc.githubRepo.GetAllCommits(ctx, username)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get language stats: %w", err)
+		return nil, fmt.Errorf("failed to get commits: %w", err)
 	}
 
-	// Calculate total bytes and prepare language stats
-	totalBytes := 0
-	for _, bytes := range languageMap {
-		totalBytes += bytes
+	repositories, commits = uc.applyWindow(repositories, commits)
+	stats := uc.buildStats(username, userProfile.CreatedAt, repositories, commits)
+
+	// Compare with earlier runs
+	if uc.history != nil {
+		if err := uc.setTrend(stats); err != nil {
+			return nil, err
+		}
 	}
 
-	languages := uc.processLanguages(languageMap, totalBytes)
+	return stats, nil
+}
 
-	// Get commits
-	commits, err := uc.githubRepo.GetAllCommits(ctx, username)
+// resolveUsername returns the target user, or the owner of the credentials
+// when none was given
+func (uc *ProfileStatsUseCase) resolveUsername(ctx context.Context) (string, error) {
+	if uc.username != "" {
+		return uc.username, nil
+	}
+
+	username, err := uc.githubRepo.GetUsername(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get commits: %w", err)
+		return "", fmt.Errorf("failed to get username: %w", err)
+	}
+	return username, nil
+}
+
+// applyWindow keeps the commits made inside the time window and the
+// repositories worked on in it. A repository that commits are attributed to
+// is kept when one of them falls inside the window. Others are kept when they
+// were pushed to after the window started, or when their push date is unknown.
+func (uc *ProfileStatsUseCase) applyWindow(repositories []domain.Repository, commits []domain.Commit) ([]domain.Repository, []domain.Commit) {
+	if uc.window.IsZero() {
+		return repositories, commits
+	}
+
+	var keptCommits []domain.Commit
+	attributed := make(map[string]bool)
+	active := make(map[string]bool)
+	for _, commit := range commits {
+		name := strings.ToLower(commit.Repository)
+		if name != "" {
+			attributed[name] = true
+		}
+		if uc.window.Contains(commit.Date) {
+			keptCommits = append(keptCommits, commit)
+			active[name] = true
+		}
+	}
+
+	var keptRepos []domain.Repository
+	for _, repo := range repositories {
+		name := strings.ToLower(repo.FullName())
+		switch {
+		case attributed[name]:
+			if active[name] {
+				keptRepos = append(keptRepos, repo)
+			}
+		case repo.PushedAt.IsZero() || uc.window.Since.IsZero() || !repo.PushedAt.Before(uc.window.Since):
+			keptRepos = append(keptRepos, repo)
+		}
+	}
+	return keptRepos, keptCommits
+}
+
+// buildStats calculates every metric from the fetched data
+func (uc *ProfileStatsUseCase) buildStats(username string, createdAt time.Time, repositories []domain.Repository, commits []domain.Commit) *domain.ProfileStats {
+	// Calculate account age
+	accountAge := uc.calculateAccountAge(createdAt)
+
+	// Calculate total bytes and prepare language stats
+	languageMap := make(map[string]int)
+	totalBytes := 0
+	for _, repo := range repositories {
+		for lang, bytes := range repo.Languages {
+			languageMap[lang] += bytes
+			totalBytes += bytes
+		}
 	}
 
+	weights := uc.weighting.Weigh(repositories, commits, uc.now(time.Local))
+	languages := uc.processLanguages(languageMap, weights)
+
+	// Move every commit onto the user's local calendar
+	commits = uc.localizeCommits(commits)
+
 	// Calculate productivity metrics
 	mostProductiveDay := uc.calculateMostProductiveDay(commits)
 	mostProductiveHour := uc.calculateMostProductiveTime(commits)
@@ -81,113 +222,132 @@ func (uc *ProfileStatsUseCase) GetProfileStats(ctx context.Context) (*domain.Pro
 	// Calculate streaks
 	currentStreak, longestStreak := uc.calculateStreaks(commits)
 
-	// Calculate weekly distribution
+	// Calculate weekly distribution and the contribution calendar
 	weeklyDistribution := uc.calculateWeeklyDistribution(commits)
+	calendar := uc.calculateContributionCalendar(commits)
 
-	return &domain.ProfileStats{
-		Username:           username,
-		Languages:          languages,
-		TotalBytes:         totalBytes,
-		MostProductiveDay:  mostProductiveDay,
-		MostProductiveHour: mostProductiveHour,
-		AccountAge:         accountAge,
-		CurrentStreak:      currentStreak,
-		LongestStreak:      longestStreak,
-		WeeklyDistribution: weeklyDistribution,
-		LastUpdated:        time.Now(),
-	}, nil
-}
+	// Calculate the punch card and the hours most commits fall into
+	punchCard := uc.calculatePunchCard(commits)
+	workingWindow := typicalWorkingWindow(punchCard)
 
-// processLanguages sorts and combines languages, showing top N languages
-func (uc *ProfileStatsUseCase) processLanguages(languageMap map[string]int, totalBytes int) []domain.LanguageStats {
-	// Guard against division by zero
-	if totalBytes == 0 {
-		return []domain.LanguageStats{}
-	}
+	// Calculate the commit series
+	monthlyCommits, yearlyCommits := uc.calculateCommitSeries(commits)
 
-	maxVisibleLanguages := uc.maxVisibleLanguages
+	// Calculate how the languages shifted over the years
+	languageEvolution := uc.calculateLanguageEvolution(repositories, commits)
 
-	// Filter out excluded languages and recalculate total bytes
-	filteredLanguageMap := make(map[string]int)
-	filteredTotalBytes := 0
-	for lang, bytes := range languageMap {
-		// Check if language should be excluded (case-insensitive)
-		excluded := false
-		for _, excludedLang := range uc.excludeLanguages {
-			if strings.ToLower(lang) == excludedLang {
-				excluded = true
-				break
-			}
-		}
-		if !excluded {
-			filteredLanguageMap[lang] = bytes
-			filteredTotalBytes += bytes
-		}
+	return &domain.ProfileStats{
+		Username:            username,
+		Languages:           languages,
+		TotalBytes:          totalBytes,
+		TotalCommits:        len(commits),
+		MostProductiveDay:   mostProductiveDay,
+		MostProductiveHour:  mostProductiveHour,
+		AccountAge:          accountAge,
+		CurrentStreak:       currentStreak,
+		LongestStreak:       longestStreak,
+		WeeklyDistribution:  weeklyDistribution,
+		LastUpdated:         time.Now(),
+		PercentagePrecision: uc.precision,
+		Window:              uc.window,
+		Weighting:           languageWeightingName(uc.weighting),
+		Calendar:            calendar,
+		PunchCard:           punchCard,
+		WorkingWindow:       workingWindow,
+		MonthlyCommits:      monthlyCommits,
+		YearlyCommits:       yearlyCommits,
+		LanguageEvolution:   languageEvolution,
 	}
+}
 
-	// If all languages are excluded, return empty
-	if filteredTotalBytes == 0 {
-		return []domain.LanguageStats{}
+// processLanguages sorts and combines languages by weight, showing top N
+// languages. Percentages follow the weights, byte counts stay as they are,
+// and display percentages are rounded to add up to 100.
+func (uc *ProfileStatsUseCase) processLanguages(languageMap map[string]int, weights map[string]float64) []domain.LanguageStats {
+	// Filter out excluded languages, merge the rest into their buckets, and
+	// filter again so whole groups can be excluded by name
+	filtered := make(map[string]languageTotal)
+	for lang, bytes := range languageMap {
+		if !uc.isExcluded(lang) {
+			filtered[lang] = languageTotal{bytes: bytes, weight: weights[lang]}
+		}
 	}
+	bucketMap := uc.languageRules.apply(filtered)
 
-	// Sort languages by bytes
-	type langPair struct {
-		name  string
-		bytes int
+	var pairs []langPair
+	totalWeight := 0.0
+	for lang, total := range bucketMap {
+		if !uc.isExcluded(lang) && total.weight > 0 {
+			pairs = append(pairs, langPair{name: lang, languageTotal: total})
+			totalWeight += total.weight
+		}
 	}
 
-	var pairs []langPair
-	for lang, bytes := range filteredLanguageMap {
-		pairs = append(pairs, langPair{lang, bytes})
+	// Guard against division by zero, e.g. when all languages are excluded
+	if totalWeight == 0 {
+		return []domain.LanguageStats{}
 	}
 
+	// Sort languages by weight
 	sort.Slice(pairs, func(i, j int) bool {
-		return pairs[i].bytes > pairs[j].bytes
-	})
-
-	// If total languages <= max count, show all
-	if len(pairs) <= maxVisibleLanguages {
-		var result []domain.LanguageStats
-		for _, pair := range pairs {
-			percentage := float64(pair.bytes) / float64(filteredTotalBytes) * 100
-			result = append(result, domain.LanguageStats{
-				Language:   pair.name,
-				Bytes:      pair.bytes,
-				Percentage: percentage,
-			})
+		if pairs[i].weight != pairs[j].weight {
+			return pairs[i].weight > pairs[j].weight
 		}
-		return result
-	}
+		return pairs[i].name < pairs[j].name
+	})
 
-	// Otherwise, show top N languages and group rest into "Other"
+	// Show top N languages plus pinned ones and group the rest into "Other"
 	var result []domain.LanguageStats
-	otherBytes := 0
+	var other languageTotal
 
 	for i, pair := range pairs {
-		percentage := float64(pair.bytes) / float64(filteredTotalBytes) * 100
-		if i < maxVisibleLanguages {
+		if len(pairs) <= uc.maxVisibleLanguages || i < uc.maxVisibleLanguages || uc.languageRules.isPinned(pair.name) {
 			result = append(result, domain.LanguageStats{
 				Language:   pair.name,
 				Bytes:      pair.bytes,
-				Percentage: percentage,
+				Percentage: pair.weight / totalWeight * 100,
 			})
 		} else {
-			otherBytes += pair.bytes
+			other.bytes += pair.bytes
+			other.weight += pair.weight
 		}
 	}
 
 	// Add "Other" category
-	if otherBytes > 0 {
+	if other.weight > 0 {
 		result = append(result, domain.LanguageStats{
 			Language:   "Other",
-			Bytes:      otherBytes,
-			Percentage: float64(otherBytes) / float64(filteredTotalBytes) * 100,
+			Bytes:      other.bytes,
+			Percentage: other.weight / totalWeight * 100,
 		})
 	}
 
+	roundPercentages(result, uc.precision)
 	return result
 }
 
+// languageTotal is the size and weight of a language or bucket
+type languageTotal struct {
+	bytes  int
+	weight float64
+}
+
+// langPair is a named languageTotal, used for sorting
+type langPair struct {
+	name string
+	languageTotal
+}
+
+// isExcluded checks whether a language or bucket is excluded (case-insensitive)
+func (uc *ProfileStatsUseCase) isExcluded(language string) bool {
+	for _, excludedLang := range uc.excludeLanguages {
+		if strings.ToLower(language) == excludedLang {
+			return true
+		}
+	}
+	return false
+}
+
 // calculateMostProductiveDay finds the weekday with most commits
 func (uc *ProfileStatsUseCase) calculateMostProductiveDay(commits []domain.Commit) string {
 	if len(commits) == 0 {
@@ -211,6 +371,10 @@ func (uc *ProfileStatsUseCase) calculateMostProductiveTime(commits []domain.Comm
 
 	hourCount := make(map[int]int)
 	for _, commit := range commits {
+		// Commits without a time of day can't tell us anything about hours
+		if commit.DateOnly {
+			continue
+		}
 		hour := commit.Date.Hour()
 		hourCount[hour]++
 	}
@@ -282,73 +446,99 @@ func (uc *ProfileStatsUseCase) calculateStreaks(commits []domain.Commit) (int, i
 		return commits[i].Date.Before(commits[j].Date)
 	})
 
-	// Get unique days with commits
-	uniqueDays := make(map[string]bool)
+	days := activeDays(commits)
+	longestStreak, _ := longestStreak(days)
+
+	// Calculate current streak (from most recent commit)
+	today := calendarDay(uc.now(commits[len(commits)-1].Date.Location()))
+	yesterday := today.AddDate(0, 0, -1)
+	mostRecentDay := days[len(days)-1]
+
+	// If last commit was today or yesterday, start counting backwards
+	currentStreak := 0
+	if mostRecentDay.Equal(today) || mostRecentDay.Equal(yesterday) {
+		currentStreak = 1
+		for i := len(days) - 2; i >= 0 && days[i+1].Sub(days[i]) == 24*time.Hour; i-- {
+			currentStreak++
+		}
+	}
+
+	return currentStreak, longestStreak
+}
+
+// activeDays returns the calendar days with commits, oldest first
+func activeDays(commits []domain.Commit) []time.Time {
+	unique := make(map[time.Time]bool)
 	for _, commit := range commits {
-		day := commit.Date.Truncate(24 * time.Hour).Format("2006-01-02")
-		uniqueDays[day] = true
+		unique[calendarDay(commit.Date)] = true
 	}
 
-	// Convert to sorted slice
-	var days []time.Time
-	for dayStr := range uniqueDays {
-		day, _ := time.Parse("2006-01-02", dayStr)
+	days := make([]time.Time, 0, len(unique))
+	for day := range unique {
 		days = append(days, day)
 	}
 	sort.Slice(days, func(i, j int) bool {
 		return days[i].Before(days[j])
 	})
+	return days
+}
 
-	if len(days) == 0 {
-		return 0, 0
+// longestStreak returns the length and first day of the longest run of
+// consecutive days in days, sorted oldest first, the earliest one on ties
+func longestStreak(days []time.Time) (int, time.Time) {
+	longest, longestStart := 0, time.Time{}
+	length := 0
+	for i, day := range days {
+		if i > 0 && day.Sub(days[i-1]) == 24*time.Hour {
+			length++
+		} else {
+			length = 1
+		}
+		if length > longest {
+			longest, longestStart = length, days[i-length+1]
+		}
 	}
+	return longest, longestStart
+}
 
-	// Calculate streaks
-	currentStreak := 0
-	longestStreak := 0
-	tempStreak := 1
-
-	for i := 0; i < len(days); i++ {
-		if i > 0 {
-			diff := days[i].Sub(days[i-1]).Hours() / 24
-			if diff == 1 {
-				tempStreak++
-			} else {
-				if tempStreak > longestStreak {
-					longestStreak = tempStreak
-				}
-				tempStreak = 1
-			}
-		}
+// localizeCommits returns a copy of commits with dates expressed in the
+// configured location. Without a location the commits are returned unchanged,
+// and date-only commits are never shifted to a different day.
+func (uc *ProfileStatsUseCase) localizeCommits(commits []domain.Commit) []domain.Commit {
+	if uc.location == nil {
+		return commits
 	}
 
-	// Check last streak
-	if tempStreak > longestStreak {
-		longestStreak = tempStreak
+	localized := make([]domain.Commit, len(commits))
+	for i, commit := range commits {
+		if !commit.DateOnly {
+			commit.Date = commit.Date.In(uc.location)
+		}
+		localized[i] = commit
 	}
+	return localized
+}
 
-	// Calculate current streak (from most recent commit)
+// now returns the current time in the configured location, falling back to
+// the given location (usually the most recent commit's offset) in auto mode
+func (uc *ProfileStatsUseCase) now(fallback *time.Location) time.Time {
 	now := time.Now()
-	today := now.Truncate(24 * time.Hour)
-	yesterday := today.Add(-24 * time.Hour)
-	mostRecentDay := days[len(days)-1]
+	// A window ending in the past is measured from its last moment
+	if !uc.window.Until.IsZero() && uc.window.Until.Before(now) {
+		now = uc.window.Until.Add(-time.Nanosecond)
+	}
 
-	// If last commit was today or yesterday, start counting backwards
-	if mostRecentDay.Equal(today) || mostRecentDay.Equal(yesterday) {
-		currentStreak = 1
-		for i := len(days) - 2; i >= 0; i-- {
-			diff := days[i+1].Sub(days[i]).Hours() / 24
-			if diff == 1 {
-				currentStreak++
-			} else {
-				break
-			}
-		}
-	} else {
-		currentStreak = 0
+	if uc.location != nil {
+		return now.In(uc.location)
 	}
+	return now.In(fallback)
+}
 
-	return currentStreak, longestStreak
+// calendarDay returns the local calendar date of t as midnight UTC, so that
+// consecutive days are always exactly 24 hours apart regardless of DST
+func calendarDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
 }
 
 // calculateWeeklyDistribution returns commit counts for each day of the week
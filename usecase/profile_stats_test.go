@@ -14,6 +14,7 @@ type MockGitHubRepository struct {
 	Username      string
 	UserProfile   *domain.UserProfile
 	LanguageStats map[string]int
+	Repositories  []domain.Repository
 	Commits       []domain.Commit
 	Err           error
 }
@@ -22,12 +23,17 @@ func (m *MockGitHubRepository) GetUsername(ctx context.Context) (string, error)
 	return m.Username, m.Err
 }
 
-func (m *MockGitHubRepository) GetUserProfile(ctx context.Context) (*domain.UserProfile, error) {
+func (m *MockGitHubRepository) GetUserProfile(ctx context.Context, username string) (*domain.UserProfile, error) {
 	return m.UserProfile, m.Err
 }
 
-func (m *MockGitHubRepository) GetLanguageStats(ctx context.Context, username string) (map[string]int, error) {
-	return m.LanguageStats, m.Err
+// GetRepositories returns Repositories, or a single repository holding
+// LanguageStats when none are given
+func (m *MockGitHubRepository) GetRepositories(ctx context.Context, username string) ([]domain.Repository, error) {
+	if m.Repositories == nil && m.LanguageStats != nil {
+		return []domain.Repository{{Owner: m.Username, Name: "repo", Languages: m.LanguageStats}}, m.Err
+	}
+	return m.Repositories, m.Err
 }
 
 func (m *MockGitHubRepository) GetAllCommits(ctx context.Context, username string) ([]domain.Commit, error) {
@@ -235,3 +241,166 @@ func TestNoExcludeLanguages(t *testing.T) {
 		t.Errorf("Expected 2 languages, got: %d", len(stats.Languages))
 	}
 }
+
+func TestLocationShiftsProductiveDayAndHour(t *testing.T) {
+	mockRepo := &MockGitHubRepository{
+		Username: "testuser",
+		UserProfile: &domain.UserProfile{
+			Username:  "testuser",
+			CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		LanguageStats: map[string]int{"Go": 1000},
+		Commits: []domain.Commit{
+			// Sunday 23:30 UTC is Monday 08:30 in Tokyo
+			{Date: time.Date(2023, 11, 12, 23, 30, 0, 0, time.UTC)},
+		},
+	}
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	uc := usecase.NewProfileStatsUseCase(mockRepo, 10, "", usecase.WithLocation(tokyo))
+	stats, err := uc.GetProfileStats(context.Background())
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if stats.MostProductiveDay != "Monday" {
+		t.Errorf("Expected most productive day 'Monday', got: %s", stats.MostProductiveDay)
+	}
+
+	if stats.MostProductiveHour != "08:00 - 09:00" {
+		t.Errorf("Expected peak hours '08:00 - 09:00', got: %s", stats.MostProductiveHour)
+	}
+
+	if stats.WeeklyDistribution["Monday"] != 1 || stats.WeeklyDistribution["Sunday"] != 0 {
+		t.Errorf("Expected the commit to be counted on Monday, got: %v", stats.WeeklyDistribution)
+	}
+}
+
+func TestLocationAffectsStreakDays(t *testing.T) {
+	mockRepo := &MockGitHubRepository{
+		Username: "testuser",
+		UserProfile: &domain.UserProfile{
+			Username:  "testuser",
+			CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		LanguageStats: map[string]int{"Go": 1000},
+		Commits: []domain.Commit{
+			{Date: time.Date(2023, 11, 12, 23, 0, 0, 0, time.UTC)},
+			{Date: time.Date(2023, 11, 13, 1, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	// In UTC the commits fall on two consecutive days
+	stats, err := usecase.NewProfileStatsUseCase(mockRepo, 10, "").GetProfileStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if stats.LongestStreak != 2 {
+		t.Errorf("Expected longest streak 2 in UTC, got: %d", stats.LongestStreak)
+	}
+
+	// Five hours behind UTC both commits happen on the same evening
+	newYork := time.FixedZone("EST", -5*60*60)
+	stats, err = usecase.NewProfileStatsUseCase(mockRepo, 10, "", usecase.WithLocation(newYork)).GetProfileStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if stats.LongestStreak != 1 {
+		t.Errorf("Expected longest streak 1 in EST, got: %d", stats.LongestStreak)
+	}
+}
+
+func TestDateOnlyCommitsSkipPeakHours(t *testing.T) {
+	mockRepo := &MockGitHubRepository{
+		Username: "testuser",
+		UserProfile: &domain.UserProfile{
+			Username:  "testuser",
+			CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		LanguageStats: map[string]int{"Go": 1000},
+		Commits: []domain.Commit{
+			{Date: time.Date(2023, 11, 13, 0, 0, 0, 0, time.UTC), DateOnly: true},
+			{Date: time.Date(2023, 11, 14, 0, 0, 0, 0, time.UTC), DateOnly: true},
+		},
+	}
+
+	// A location west of UTC must not move date-only commits to the previous day
+	newYork := time.FixedZone("EST", -5*60*60)
+	uc := usecase.NewProfileStatsUseCase(mockRepo, 10, "", usecase.WithLocation(newYork))
+	stats, err := uc.GetProfileStats(context.Background())
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if stats.MostProductiveHour != "N/A" {
+		t.Errorf("Expected peak hours 'N/A' without times of day, got: %s", stats.MostProductiveHour)
+	}
+
+	if stats.WeeklyDistribution["Monday"] != 1 || stats.WeeklyDistribution["Tuesday"] != 1 {
+		t.Errorf("Expected commits on Monday and Tuesday, got: %v", stats.WeeklyDistribution)
+	}
+}
+
+// RecordingGitHubRepository records which usernames the use case asked for
+type RecordingGitHubRepository struct {
+	MockGitHubRepository
+	UsernameCalls    int
+	ProfileUsernames []string
+	StatsUsernames   []string
+}
+
+func (r *RecordingGitHubRepository) GetUsername(ctx context.Context) (string, error) {
+	r.UsernameCalls++
+	return r.MockGitHubRepository.GetUsername(ctx)
+}
+
+func (r *RecordingGitHubRepository) GetUserProfile(ctx context.Context, username string) (*domain.UserProfile, error) {
+	r.ProfileUsernames = append(r.ProfileUsernames, username)
+	return r.MockGitHubRepository.GetUserProfile(ctx, username)
+}
+
+func (r *RecordingGitHubRepository) GetRepositories(ctx context.Context, username string) ([]domain.Repository, error) {
+	r.StatsUsernames = append(r.StatsUsernames, username)
+	return r.MockGitHubRepository.GetRepositories(ctx, username)
+}
+
+func (r *RecordingGitHubRepository) GetAllCommits(ctx context.Context, username string) ([]domain.Commit, error) {
+	r.StatsUsernames = append(r.StatsUsernames, username)
+	return r.MockGitHubRepository.GetAllCommits(ctx, username)
+}
+
+func TestWithUsernameSkipsDiscovery(t *testing.T) {
+	repo := &RecordingGitHubRepository{
+		MockGitHubRepository: MockGitHubRepository{
+			Username: "token-owner",
+			UserProfile: &domain.UserProfile{
+				Username:  "octocat",
+				CreatedAt: time.Date(2011, 1, 25, 0, 0, 0, 0, time.UTC),
+			},
+			LanguageStats: map[string]int{"Go": 100},
+		},
+	}
+
+	uc := usecase.NewProfileStatsUseCase(repo, 10, "", usecase.WithUsername("octocat"))
+	stats, err := uc.GetProfileStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if repo.UsernameCalls != 0 {
+		t.Errorf("Expected GetUsername not to be called, got %d calls", repo.UsernameCalls)
+	}
+	if len(repo.ProfileUsernames) != 1 || repo.ProfileUsernames[0] != "octocat" {
+		t.Errorf("Expected profile lookup for octocat, got: %v", repo.ProfileUsernames)
+	}
+	for _, username := range repo.StatsUsernames {
+		if username != "octocat" {
+			t.Errorf("Expected stats for octocat, got request for %q", username)
+		}
+	}
+	if stats.Username != "octocat" {
+		t.Errorf("Expected username 'octocat', got: %s", stats.Username)
+	}
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"time"
+
+	"GitInsights/domain"
+)
+
+// workingWindowShare is the smallest share of commits, in percent, a typical
+// working window must hold
+const workingWindowShare = 50
+
+// calculatePunchCard counts commits by weekday and hour, skipping those
+// without a time of day
+func (uc *ProfileStatsUseCase) calculatePunchCard(commits []domain.Commit) domain.PunchCard {
+	var card domain.PunchCard
+	for _, commit := range commits {
+		if commit.DateOnly {
+			continue
+		}
+		card[commit.Date.Weekday()][commit.Date.Hour()]++
+	}
+	return card
+}
+
+// typicalWorkingWindow finds the densest block of consecutive weekdays, from
+// Monday to Sunday, and consecutive hours, possibly past midnight, that holds
+// at least half of the commits. Among equally dense blocks the smallest wins.
+// It returns nil for an empty punch card.
+func typicalWorkingWindow(card domain.PunchCard) *domain.WorkingWindow {
+	total := 0
+	for _, hours := range card {
+		for _, count := range hours {
+			total += count
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	// mondayFirst maps a row of the week starting on Monday to its weekday
+	mondayFirst := func(row int) time.Weekday {
+		return time.Weekday((row + 1) % 7)
+	}
+
+	var best *domain.WorkingWindow
+	bestCells := 0
+	for first := 0; first < 7; first++ {
+		var hourly [24]int
+		for last := first; last < 7; last++ {
+			for hour, count := range card[mondayFirst(last)] {
+				hourly[hour] += count
+			}
+			days := last - first + 1
+
+			for start := 0; start < 24; start++ {
+				sum := 0
+				for length := 1; length <= 24; length++ {
+					sum += hourly[(start+length-1)%24]
+					if sum*100 < total*workingWindowShare {
+						continue
+					}
+
+					// Keep denser or equally dense smaller blocks; densities are
+					// compared without division
+					cells := days * length
+					if best != nil && (sum*bestCells < best.Commits*cells ||
+						(sum*bestCells == best.Commits*cells && cells >= bestCells)) {
+						continue
+					}
+					best = &domain.WorkingWindow{
+						FirstDay:  mondayFirst(first),
+						LastDay:   mondayFirst(last),
+						StartHour: start,
+						EndHour:   (start + length) % 24,
+						Commits:   sum,
+						Share:     float64(sum) / float64(total) * 100,
+					}
+					bestCells = cells
+				}
+			}
+		}
+	}
+	return best
+}
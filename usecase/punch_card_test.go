@@ -0,0 +1,101 @@
+package usecase_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"GitInsights/domain"
+	"GitInsights/usecase"
+)
+
+func punchCardStats(t *testing.T, commits []domain.Commit) *domain.ProfileStats {
+	t.Helper()
+
+	mockRepo := &MockGitHubRepository{
+		Username: "testuser",
+		UserProfile: &domain.UserProfile{
+			Username:  "testuser",
+			CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Commits: commits,
+	}
+
+	uc := usecase.NewProfileStatsUseCase(mockRepo, 10, "", usecase.WithLocation(time.UTC))
+	stats, err := uc.GetProfileStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	return stats
+}
+
+func TestPunchCard(t *testing.T) {
+	var commits []domain.Commit
+	// Office hours from Monday, November 13, 2023 to Friday, 10:00-12:00
+	for day := 13; day <= 17; day++ {
+		for hour := 10; hour < 12; hour++ {
+			commits = append(commits, domain.Commit{Date: time.Date(2023, 11, day, hour, 30, 0, 0, time.UTC)})
+		}
+	}
+	// A few late nights and a date-only commit
+	commits = append(commits,
+		domain.Commit{Date: time.Date(2023, 11, 18, 23, 15, 0, 0, time.UTC)},
+		domain.Commit{Date: time.Date(2023, 11, 19, 0, 45, 0, 0, time.UTC)},
+		domain.Commit{Date: time.Date(2023, 11, 19, 0, 0, 0, 0, time.UTC), DateOnly: true},
+	)
+
+	stats := punchCardStats(t, commits)
+
+	if stats.PunchCard[time.Monday][10] != 1 || stats.PunchCard[time.Friday][11] != 1 {
+		t.Errorf("Expected one commit in each office hour, got: %v", stats.PunchCard)
+	}
+	if stats.PunchCard[time.Saturday][23] != 1 || stats.PunchCard[time.Sunday][0] != 1 {
+		t.Errorf("Expected the late nights on Saturday and Sunday, got: %v", stats.PunchCard)
+	}
+	total := 0
+	for _, hours := range stats.PunchCard {
+		for _, count := range hours {
+			total += count
+		}
+	}
+	if total != 12 {
+		t.Errorf("Expected the date-only commit to be left out, got %d commits", total)
+	}
+
+	window := stats.WorkingWindow
+	if window == nil {
+		t.Fatal("Expected a working window")
+	}
+	// Six of twelve commits fill Monday to Wednesday, 10:00-12:00 completely
+	if window.FirstDay != time.Monday || window.LastDay != time.Wednesday || window.StartHour != 10 || window.EndHour != 12 {
+		t.Errorf("Expected Monday-Wednesday 10:00-12:00, got: %+v", window)
+	}
+	if window.Commits != 6 || math.Abs(window.Share-50) > 0.01 {
+		t.Errorf("Expected 6 commits or 50%%, got: %+v", window)
+	}
+}
+
+func TestWorkingWindowPastMidnight(t *testing.T) {
+	// Tuesday, November 14, 2023 around midnight, plus two lone commits
+	tuesday := func(hour, minute int) domain.Commit {
+		return domain.Commit{Date: time.Date(2023, 11, 14, hour, minute, 0, 0, time.UTC)}
+	}
+	commits := []domain.Commit{
+		tuesday(23, 10), tuesday(23, 40), tuesday(0, 20), tuesday(0, 50),
+		{Date: time.Date(2023, 11, 13, 12, 0, 0, 0, time.UTC)},
+		{Date: time.Date(2023, 11, 15, 9, 0, 0, 0, time.UTC)},
+	}
+
+	window := punchCardStats(t, commits).WorkingWindow
+	if window == nil || window.FirstDay != time.Tuesday || window.LastDay != time.Tuesday || window.StartHour != 23 || window.EndHour != 1 {
+		t.Errorf("Expected Tuesday 23:00-01:00, got: %+v", window)
+	}
+}
+
+func TestWorkingWindowWithoutTimes(t *testing.T) {
+	stats := punchCardStats(t, []domain.Commit{{Date: time.Date(2023, 11, 13, 0, 0, 0, 0, time.UTC), DateOnly: true}})
+	if stats.WorkingWindow != nil {
+		t.Errorf("Expected no working window without times of day, got: %+v", stats.WorkingWindow)
+	}
+}
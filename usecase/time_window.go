@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"GitInsights/domain"
+)
+
+// ParseTimeWindow resolves --since and --until values relative to now. Each
+// value is empty (open), a date (2006-01-02), an RFC 3339 time, a duration
+// back from now ("30d", "12w", "6m", "2y"), or a named period ("today",
+// "this-week", "this-month", "last-month", "this-year", "last-year"). Named
+// periods and dates resolve to their start for since and to their end for
+// until, so "--since last-year --until last-year" covers all of last year.
+func ParseTimeWindow(since, until string, now time.Time) (domain.TimeWindow, error) {
+	var window domain.TimeWindow
+	var err error
+
+	if since != "" {
+		if window.Since, err = parseTimeBound(since, now, false); err != nil {
+			return domain.TimeWindow{}, fmt.Errorf("invalid since %q: %w", since, err)
+		}
+	}
+	if until != "" {
+		if window.Until, err = parseTimeBound(until, now, true); err != nil {
+			return domain.TimeWindow{}, fmt.Errorf("invalid until %q: %w", until, err)
+		}
+	}
+
+	if !window.Since.IsZero() && !window.Until.IsZero() && !window.Since.Before(window.Until) {
+		return domain.TimeWindow{}, fmt.Errorf("since %q is not before until %q", since, until)
+	}
+	if !window.IsZero() {
+		window.Spec = since + ".." + until
+	}
+	return window, nil
+}
+
+// parseTimeBound resolves one bound; end selects the end of periods and dates
+// instead of their start
+func parseTimeBound(value string, now time.Time, end bool) (time.Time, error) {
+	year, month, day := now.Date()
+	loc := now.Location()
+	today := time.Date(year, month, day, 0, 0, 0, 0, loc)
+
+	// period returns the start or the end of [start, start + years/months/days)
+	period := func(start time.Time, years, months, days int) time.Time {
+		if end {
+			return start.AddDate(years, months, days)
+		}
+		return start
+	}
+
+	switch value {
+	case "now":
+		return now, nil
+	case "today":
+		return period(today, 0, 0, 1), nil
+	case "this-week":
+		// Weeks start on Monday
+		offset := (int(today.Weekday()) + 6) % 7
+		return period(today.AddDate(0, 0, -offset), 0, 0, 7), nil
+	case "this-month":
+		return period(time.Date(year, month, 1, 0, 0, 0, 0, loc), 0, 1, 0), nil
+	case "last-month":
+		return period(time.Date(year, month-1, 1, 0, 0, 0, 0, loc), 0, 1, 0), nil
+	case "this-year":
+		return period(time.Date(year, 1, 1, 0, 0, 0, 0, loc), 1, 0, 0), nil
+	case "last-year":
+		return period(time.Date(year-1, 1, 1, 0, 0, 0, 0, loc), 1, 0, 0), nil
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return period(date, 0, 0, 1), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	// Durations back from now: 30d, 12w, 6m, 2y
+	if len(value) >= 2 {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err == nil && n >= 0 {
+			switch strings.ToLower(value[len(value)-1:]) {
+			case "d":
+				return now.AddDate(0, 0, -n), nil
+			case "w":
+				return now.AddDate(0, 0, -7*n), nil
+			case "m":
+				return now.AddDate(0, -n, 0), nil
+			case "y":
+				return now.AddDate(-n, 0, 0), nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("expected a date, a duration like 365d or a period like this-year")
+}
@@ -0,0 +1,105 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"GitInsights/domain"
+	"GitInsights/usecase"
+)
+
+func TestParseTimeWindow(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2024, 3, 13, 15, 30, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		since, until string
+		want         domain.TimeWindow
+	}{
+		{"", "", domain.TimeWindow{}},
+		{"365d", "", domain.TimeWindow{Since: now.AddDate(0, 0, -365)}},
+		{"6m", "now", domain.TimeWindow{Since: now.AddDate(0, -6, 0), Until: now}},
+		{"2y", "", domain.TimeWindow{Since: now.AddDate(-2, 0, 0)}},
+		{"this-year", "", domain.TimeWindow{Since: date(2024, 1, 1)}},
+		{"last-year", "last-year", domain.TimeWindow{Since: date(2023, 1, 1), Until: date(2024, 1, 1)}},
+		{"last-month", "last-month", domain.TimeWindow{Since: date(2024, 2, 1), Until: date(2024, 3, 1)}},
+		{"this-week", "today", domain.TimeWindow{Since: date(2024, 3, 11), Until: date(2024, 3, 14)}},
+		{"2023-06-01", "2023-06-30", domain.TimeWindow{Since: date(2023, 6, 1), Until: date(2023, 7, 1)}},
+	}
+
+	for _, tt := range tests {
+		got, err := usecase.ParseTimeWindow(tt.since, tt.until, now)
+		if err != nil {
+			t.Errorf("ParseTimeWindow(%q, %q) failed: %v", tt.since, tt.until, err)
+			continue
+		}
+		if !got.Since.Equal(tt.want.Since) || !got.Until.Equal(tt.want.Until) {
+			t.Errorf("ParseTimeWindow(%q, %q) = %v - %v, want %v - %v",
+				tt.since, tt.until, got.Since, got.Until, tt.want.Since, tt.want.Until)
+		}
+	}
+
+	for _, bad := range [][2]string{{"yesterdayish", ""}, {"this-year", "last-year"}, {"-3d", ""}} {
+		if _, err := usecase.ParseTimeWindow(bad[0], bad[1], now); err == nil {
+			t.Errorf("Expected an error for since %q until %q", bad[0], bad[1])
+		}
+	}
+}
+
+func TestTimeWindowFiltersCommitsAndRepositories(t *testing.T) {
+	window := domain.TimeWindow{
+		Since: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		Until: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	mockRepo := &MockGitHubRepository{
+		Username: "testuser",
+		UserProfile: &domain.UserProfile{
+			Username:  "testuser",
+			CreatedAt: time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Repositories: []domain.Repository{
+			{Name: "old", Languages: map[string]int{"Perl": 5000}, PushedAt: time.Date(2016, 5, 1, 0, 0, 0, 0, time.UTC)},
+			{Name: "new", Languages: map[string]int{"Go": 1000}, PushedAt: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+			// Pushed inside the window, but only worked on after it
+			{Name: "later", Languages: map[string]int{"Rust": 8000}, PushedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+			// No commits to tell, but pushed before the window
+			{Name: "idle", Languages: map[string]int{"Ruby": 3000}, PushedAt: time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		Commits: []domain.Commit{
+			{SHA: "a", Date: time.Date(2016, 5, 1, 10, 0, 0, 0, time.UTC), Repository: "old"},
+			{SHA: "b", Date: time.Date(2023, 12, 30, 10, 0, 0, 0, time.UTC), Repository: "new"},
+			{SHA: "c", Date: time.Date(2023, 12, 31, 10, 0, 0, 0, time.UTC), Repository: "new"},
+			{SHA: "d", Date: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), Repository: "later"},
+		},
+	}
+
+	uc := usecase.NewProfileStatsUseCase(mockRepo, 10, "", usecase.WithTimeWindow(window))
+	stats, err := uc.GetProfileStats(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if stats.Window != window {
+		t.Errorf("Expected the window to be recorded, got: %+v", stats.Window)
+	}
+	if len(stats.Languages) != 1 || stats.Languages[0].Language != "Go" {
+		t.Errorf("Expected only languages of repositories worked on in the window, got: %+v", stats.Languages)
+	}
+
+	total := 0
+	for _, count := range stats.WeeklyDistribution {
+		total += count
+	}
+	if total != 2 {
+		t.Errorf("Expected the 2 commits inside the window, got: %d", total)
+	}
+
+	// The window ended on a streak, which stays current
+	if stats.CurrentStreak != 2 || stats.LongestStreak != 2 {
+		t.Errorf("Expected a current streak of 2 at the end of the window, got: %d/%d", stats.CurrentStreak, stats.LongestStreak)
+	}
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"GitInsights/domain"
+)
+
+// maxTopRepositories is the number of repositories listed in a year review
+const maxTopRepositories = 5
+
+// YearReviewUseCase summarizes one calendar year of a user's activity. It
+// shares the profile's options, such as the location, target user and
+// language rules.
+type YearReviewUseCase struct {
+	githubRepo domain.GitHubRepository
+	profile    *ProfileStatsUseCase
+	year       int
+}
+
+// NewYearReviewUseCase creates a new instance for the given year
+func NewYearReviewUseCase(githubRepo domain.GitHubRepository, year int, excludeLanguagesStr string, opts ...Option) *YearReviewUseCase {
+	return &YearReviewUseCase{
+		githubRepo: githubRepo,
+		profile:    NewProfileStatsUseCase(githubRepo, 0, excludeLanguagesStr, opts...),
+		year:       year,
+	}
+}
+
+// GetYearReview retrieves the user's activity and summarizes the year
+func (uc *YearReviewUseCase) GetYearReview(ctx context.Context) (*domain.YearReview, error) {
+	username, err := uc.profile.resolveUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	repositories, err := uc.githubRepo.GetRepositories(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repositories: %w", err)
+	}
+
+	commits, err := uc.githubRepo.GetAllCommits(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits: %w", err)
+	}
+
+	return uc.buildReview(username, repositories, commits), nil
+}
+
+// buildReview calculates every figure of the review from the fetched data
+func (uc *YearReviewUseCase) buildReview(username string, repositories []domain.Repository, commits []domain.Commit) *domain.YearReview {
+	// Split the user's local calendar into this year, last year and before
+	var thisYear, lastYear, earlier []domain.Commit
+	for _, commit := range uc.profile.localizeCommits(commits) {
+		switch year := commit.Date.Year(); {
+		case year == uc.year:
+			thisYear = append(thisYear, commit)
+		case year < uc.year:
+			earlier = append(earlier, commit)
+			if year == uc.year-1 {
+				lastYear = append(lastYear, commit)
+			}
+		}
+	}
+
+	review := &domain.YearReview{
+		Username:    username,
+		Year:        uc.year,
+		YearTotals:  yearTotals(thisYear),
+		Previous:    yearTotals(lastYear),
+		LastUpdated: time.Now(),
+	}
+	_, review.LongestStreakStart = longestStreak(activeDays(thisYear))
+
+	// Busiest month and week, the earliest one on ties
+	monthCommits := make(map[time.Month]int)
+	weekCommits := make(map[time.Time]int)
+	for _, commit := range thisYear {
+		monthCommits[commit.Date.Month()]++
+		day := calendarDay(commit.Date)
+		weekCommits[day.AddDate(0, 0, -(int(day.Weekday())+6)%7)]++
+	}
+	for month := time.January; month <= time.December; month++ {
+		if monthCommits[month] > review.BusiestMonthCommits {
+			review.BusiestMonth, review.BusiestMonthCommits = month, monthCommits[month]
+		}
+	}
+	for week, count := range weekCommits {
+		if count > review.BusiestWeekCommits || (count == review.BusiestWeekCommits && week.Before(review.BusiestWeekStart)) {
+			review.BusiestWeekStart, review.BusiestWeekCommits = week, count
+		}
+	}
+
+	review.TopRepositories = topRepositories(thisYear, maxTopRepositories)
+	review.NewLanguages = uc.newLanguages(repositories, thisYear, earlier)
+
+	return review
+}
+
+// newLanguages lists the languages of the repositories committed to this
+// year that none of the earlier commits touched, most used first
+func (uc *YearReviewUseCase) newLanguages(repositories []domain.Repository, thisYear, earlier []domain.Commit) []string {
+	reposByName := make(map[string]domain.Repository, len(repositories))
+	for _, repo := range repositories {
+		reposByName[strings.ToLower(repo.FullName())] = repo
+	}
+
+	// languagesOf sums the bucketed languages of every repository committed to
+	languagesOf := func(commits []domain.Commit) map[string]int {
+		seen := make(map[string]bool)
+		languages := make(map[string]int)
+		for _, commit := range commits {
+			name := strings.ToLower(commit.Repository)
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			for lang, bytes := range reposByName[name].Languages {
+				if bucket := uc.profile.languageRules.bucket(lang); !uc.profile.isExcluded(lang) && !uc.profile.isExcluded(bucket) {
+					languages[bucket] += bytes
+				}
+			}
+		}
+		return languages
+	}
+
+	used := languagesOf(thisYear)
+	known := languagesOf(earlier)
+
+	var adopted []string
+	for lang := range used {
+		if _, ok := known[lang]; !ok {
+			adopted = append(adopted, lang)
+		}
+	}
+	sort.Slice(adopted, func(i, j int) bool {
+		if used[adopted[i]] != used[adopted[j]] {
+			return used[adopted[i]] > used[adopted[j]]
+		}
+		return adopted[i] < adopted[j]
+	})
+	return adopted
+}
+
+// yearTotals counts commits, active days, repositories and the longest streak
+func yearTotals(commits []domain.Commit) domain.YearTotals {
+	repositories := make(map[string]bool)
+	for _, commit := range commits {
+		if commit.Repository != "" {
+			repositories[strings.ToLower(commit.Repository)] = true
+		}
+	}
+
+	days := activeDays(commits)
+	streak, _ := longestStreak(days)
+	return domain.YearTotals{
+		Commits:       len(commits),
+		ActiveDays:    len(days),
+		LongestStreak: streak,
+		Repositories:  len(repositories),
+	}
+}
+
+// topRepositories ranks repositories by their number of commits
+func topRepositories(commits []domain.Commit, limit int) []domain.RepositoryActivity {
+	counts := make(map[string]int)
+	for _, commit := range commits {
+		if commit.Repository != "" {
+			counts[commit.Repository]++
+		}
+	}
+
+	ranked := make([]domain.RepositoryActivity, 0, len(counts))
+	for name, count := range counts {
+		ranked = append(ranked, domain.RepositoryActivity{Name: name, Commits: count})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Commits != ranked[j].Commits {
+			return ranked[i].Commits > ranked[j].Commits
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
@@ -0,0 +1,86 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"GitInsights/domain"
+	"GitInsights/usecase"
+)
+
+func TestGetYearReview(t *testing.T) {
+	commit := func(repo string, month time.Month, day int) domain.Commit {
+		return domain.Commit{Date: time.Date(2023, month, day, 10, 0, 0, 0, time.UTC), Repository: repo}
+	}
+	commits := []domain.Commit{
+		// 2022: Go only
+		{Date: time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC), Repository: "octo/api"},
+		{Date: time.Date(2022, 3, 2, 10, 0, 0, 0, time.UTC), Repository: "octo/api"},
+		// 2023
+		commit("octo/api", time.March, 6),
+		commit("octo/api", time.March, 7),
+		commit("octo/api", time.March, 8),
+		commit("octo/web", time.March, 9),
+		commit("octo/web", time.June, 1),
+		commit("octo/cli", time.June, 20),
+		commit("octo/cli", time.June, 21),
+		// Next year is ignored
+		{Date: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC), Repository: "octo/api"},
+	}
+	mockRepo := &MockGitHubRepository{
+		Username: "octo",
+		Repositories: []domain.Repository{
+			{Owner: "octo", Name: "api", Languages: map[string]int{"Go": 5000}},
+			{Owner: "octo", Name: "web", Languages: map[string]int{"TypeScript": 3000, "CSS": 500}},
+			{Owner: "octo", Name: "cli", Languages: map[string]int{"Rust": 4000, "Go": 100}},
+		},
+		Commits: commits,
+	}
+
+	uc := usecase.NewYearReviewUseCase(mockRepo, 2023, "css")
+	review, err := uc.GetYearReview(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	want := domain.YearTotals{Commits: 7, ActiveDays: 7, LongestStreak: 4, Repositories: 3}
+	if review.YearTotals != want {
+		t.Errorf("Expected totals %+v, got: %+v", want, review.YearTotals)
+	}
+	wantPrevious := domain.YearTotals{Commits: 2, ActiveDays: 2, LongestStreak: 2, Repositories: 1}
+	if review.Previous != wantPrevious {
+		t.Errorf("Expected previous totals %+v, got: %+v", wantPrevious, review.Previous)
+	}
+
+	if review.BusiestMonth != time.March || review.BusiestMonthCommits != 4 {
+		t.Errorf("Expected March with 4 commits, got: %s with %d", review.BusiestMonth, review.BusiestMonthCommits)
+	}
+	if !review.BusiestWeekStart.Equal(time.Date(2023, 3, 6, 0, 0, 0, 0, time.UTC)) || review.BusiestWeekCommits != 4 {
+		t.Errorf("Expected the week of March 6 with 4 commits, got: %s with %d", review.BusiestWeekStart, review.BusiestWeekCommits)
+	}
+	if !review.LongestStreakStart.Equal(time.Date(2023, 3, 6, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the longest streak to start on March 6, got: %s", review.LongestStreakStart)
+	}
+
+	// Go was used before; CSS is excluded
+	if got := strings.Join(review.NewLanguages, ","); got != "Rust,TypeScript" {
+		t.Errorf("Expected Rust and TypeScript as new languages, got: %s", got)
+	}
+
+	if len(review.TopRepositories) != 3 || review.TopRepositories[0] != (domain.RepositoryActivity{Name: "octo/api", Commits: 3}) {
+		t.Errorf("Expected octo/api first with 3 commits, got: %+v", review.TopRepositories)
+	}
+	if review.TopRepositories[1].Name != "octo/cli" || review.TopRepositories[2].Name != "octo/web" {
+		t.Errorf("Expected ties ordered by name, got: %+v", review.TopRepositories)
+	}
+}
+
+func TestGetYearReviewError(t *testing.T) {
+	mockRepo := &MockGitHubRepository{Err: context.DeadlineExceeded}
+	uc := usecase.NewYearReviewUseCase(mockRepo, 2023, "")
+	if _, err := uc.GetYearReview(context.Background()); err == nil {
+		t.Error("Expected an error, got nil")
+	}
+}